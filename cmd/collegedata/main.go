@@ -0,0 +1,134 @@
+// Command collegedata imports and exports college_details without going
+// through the HTTP API.
+//
+//	go run ./cmd/collegedata import -format csv -file colleges.csv
+//	go run ./cmd/collegedata export -format jsonl -country India -out india.jsonl
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gobackend/config"
+	"gobackend/services"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("⚠️ No .env file found, using environment variables")
+	}
+
+	if err := config.ConnectDatabase(); err != nil {
+		log.Fatal(" MongoDB connection failed:", err)
+	}
+	if config.CollegeCollection == nil {
+		log.Fatal(" MONGO_URI must be set")
+	}
+	defer config.DisconnectDatabase()
+
+	switch os.Args[1] {
+	case "import":
+		runImport(os.Args[2:])
+	case "export":
+		runExport(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: collegedata import|export [flags]")
+	os.Exit(2)
+}
+
+func runImport(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	file := fs.String("file", "-", "file to read, - for stdin")
+	format := fs.String("format", "", "jsonl or csv (default: from file extension)")
+	fs.Parse(args)
+
+	var in io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	report, err := services.ImportColleges(in, resolveFormat(*format, *file))
+	if report != nil {
+		out, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(out))
+	}
+	if err != nil {
+		log.Fatal("❌ Import failed: ", err)
+	}
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func runExport(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	out := fs.String("out", "-", "file to write, - for stdout")
+	format := fs.String("format", "", "jsonl or csv (default: from file extension)")
+	country := fs.String("country", "", "only export this country")
+	name := fs.String("name", "", "only export colleges whose name contains this text")
+	since := fs.String("updated-since", "", "only export colleges updated at or after this RFC 3339 time")
+	includeDeleted := fs.Bool("include-deleted", false, "include soft deleted colleges")
+	fs.Parse(args)
+
+	filter := services.ExportFilter{Country: *country, Name: *name, IncludeDeleted: *includeDeleted}
+	if *since != "" {
+		t, err := time.Parse(time.RFC3339, *since)
+		if err != nil {
+			log.Fatal("updated-since must be an RFC 3339 timestamp")
+		}
+		filter.UpdatedSince = t
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	buffered := bufio.NewWriter(w)
+	defer buffered.Flush()
+
+	count, err := services.ExportColleges(context.Background(), buffered, resolveFormat(*format, *out), filter)
+	if err != nil {
+		log.Fatal("❌ Export failed: ", err)
+	}
+	log.Printf("📤 Exported %d colleges", count)
+}
+
+func resolveFormat(format, path string) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return services.FormatCSV
+	}
+	return services.FormatJSONL
+}
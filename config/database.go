@@ -14,6 +14,9 @@ var (
 	Client            *mongo.Client
 	TruDB             *mongo.Database
 	CollegeCollection *mongo.Collection
+	StreamTokens      *mongo.Collection
+	EventLog          *mongo.Collection
+	EventBus          *mongo.Collection
 )
 
 func ConnectDatabase() error {
@@ -34,6 +37,9 @@ func ConnectDatabase() error {
 
 	TruDB = Client.Database("tru")
 	CollegeCollection = TruDB.Collection("college_details")
+	StreamTokens = TruDB.Collection("change_stream_tokens")
+	EventLog = TruDB.Collection("college_events")
+	EventBus = TruDB.Collection("event_bus")
 	log.Println("Connected to MongoDB - Database: tru, Collection: college_details")
 
 	return nil
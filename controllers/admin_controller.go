@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"gobackend/models"
+	"gobackend/services"
+	"gobackend/utils"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func GetCollege(w http.ResponseWriter, r *http.Request) {
+	fields, ok := parseCollegeFields(w, r)
+	if !ok {
+		return
+	}
+
+	college, err := services.GetCollegeByID(mux.Vars(r)["id"], false)
+	if err != nil {
+		respondAdminError(w, err)
+		return
+	}
+
+	utils.SetLastModified(w, college.UpdatedAt)
+	utils.RespondSuccess(w, http.StatusOK, utils.SelectFields(college, fields), nil)
+}
+
+func CreateCollege(w http.ResponseWriter, r *http.Request) {
+	var stats models.CollegeStats
+	if !decodeCollegeBody(w, r, &stats) {
+		return
+	}
+	stats.ID = primitive.NilObjectID
+
+	created, err := services.CreateCollege(&stats)
+	if err != nil {
+		respondAdminError(w, err)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusCreated, created, nil)
+}
+
+func ReplaceCollege(w http.ResponseWriter, r *http.Request) {
+	var stats models.CollegeStats
+	if !decodeCollegeBody(w, r, &stats) {
+		return
+	}
+
+	updated, err := services.ReplaceCollege(mux.Vars(r)["id"], &stats)
+	if err != nil {
+		respondAdminError(w, err)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, updated, nil)
+}
+
+func PatchCollege(w http.ResponseWriter, r *http.Request) {
+	var patch map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		respondInvalidBody(w, err)
+		return
+	}
+
+	updated, err := services.PatchCollege(mux.Vars(r)["id"], patch)
+	if err != nil {
+		respondAdminError(w, err)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, updated, nil)
+}
+
+func DeleteCollege(w http.ResponseWriter, r *http.Request) {
+	if err := services.DeleteCollege(mux.Vars(r)["id"]); err != nil {
+		respondAdminError(w, err)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, map[string]string{"status": "deleted"}, nil)
+}
+
+func RestoreCollege(w http.ResponseWriter, r *http.Request) {
+	restored, err := services.RestoreCollege(mux.Vars(r)["id"])
+	if err != nil {
+		respondAdminError(w, err)
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, restored, nil)
+}
+
+// decodeCollegeBody parses a full CollegeStats payload, rejecting unknown
+// fields so typos don't silently drop data.
+func decodeCollegeBody(w http.ResponseWriter, r *http.Request, stats *models.CollegeStats) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(stats); err != nil {
+		respondInvalidBody(w, err)
+		return false
+	}
+	return true
+}
+
+func respondInvalidBody(w http.ResponseWriter, err error) {
+	utils.RespondAPIError(w, http.StatusBadRequest, models.APIError{
+		Code:    models.ErrCodeBadRequest,
+		Message: "invalid JSON body",
+		Details: err.Error(),
+	})
+}
+
+func respondAdminError(w http.ResponseWriter, err error) {
+	var validationErrs models.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrs):
+		utils.RespondAPIError(w, http.StatusUnprocessableEntity, models.APIError{
+			Code:    models.ErrCodeValidation,
+			Message: "validation failed",
+			Fields:  validationErrs,
+		})
+	case errors.Is(err, services.ErrInvalidID):
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
+	case errors.Is(err, services.ErrCollegeNotFound):
+		utils.RespondError(w, http.StatusNotFound, models.ErrCodeNotFound, err.Error())
+	case errors.Is(err, services.ErrCollegeExists):
+		utils.RespondError(w, http.StatusConflict, models.ErrCodeConflict, err.Error())
+	default:
+		log.Printf("❌ Admin college operation failed: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, models.ErrCodeInternal, "internal error")
+	}
+}
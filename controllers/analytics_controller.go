@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"gobackend/models"
+	"gobackend/services"
+	"gobackend/utils"
+
+	"github.com/gorilla/mux"
+)
+
+func GetCountryAnalytics(w http.ResponseWriter, r *http.Request) {
+	analytics, err := services.GetCountryAnalytics()
+	if err != nil {
+		log.Printf(" Error computing analytics: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, models.ErrCodeInternal, "Failed to compute analytics")
+		return
+	}
+
+	utils.SetLastModified(w, analytics.GeneratedAt)
+	utils.RespondSuccess(w, http.StatusOK, analytics, nil)
+}
+
+func GetCountryAnalyticsByName(w http.ResponseWriter, r *http.Request) {
+	country := mux.Vars(r)["country"]
+
+	analytics, generatedAt, err := services.GetAnalyticsForCountry(country)
+	if errors.Is(err, services.ErrCountryNotFound) {
+		utils.RespondError(w, http.StatusNotFound, models.ErrCodeNotFound, "No colleges found for "+country)
+		return
+	}
+	if err != nil {
+		log.Printf(" Error computing analytics: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, models.ErrCodeInternal, "Failed to compute analytics")
+		return
+	}
+
+	utils.SetLastModified(w, generatedAt)
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"generated_at": generatedAt,
+		"country":      analytics,
+	}, nil)
+}
@@ -1,9 +1,15 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 
+	"gobackend/models"
 	"gobackend/services"
 	"gobackend/utils"
 )
@@ -12,42 +18,38 @@ func GetCollegeStatistics(w http.ResponseWriter, r *http.Request) {
 	collegeName := r.URL.Query().Get("college_name")
 
 	if collegeName == "" {
-		utils.RespondJSON(w, http.StatusBadRequest, map[string]string{"error": "college_name required"})
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, "college_name required")
+		return
+	}
+
+	fields, ok := parseCollegeFields(w, r)
+	if !ok {
 		return
 	}
 
 	log.Printf("📊 Fetching stats for: %s", collegeName)
 
-	cachedResult, err := services.GetCollegeFromCache(collegeName)
-	if err == nil {
-		go services.CompareAndUpdateCache(collegeName, *cachedResult)
-		utils.RespondJSON(w, http.StatusOK, cachedResult)
+	stats, cached, err := services.ResolveCollege(collegeName)
+	if errors.Is(err, services.ErrCollegeDeleted) {
+		utils.RespondError(w, http.StatusGone, models.ErrCodeGone, "College has been removed")
 		return
 	}
-
-	log.Println("🔄 Calling Gemini API directly...")
-	stats, err := services.FetchCollegeDataFromGemini(collegeName)
 	if err != nil {
 		log.Printf(" Gemini API error: %v", err)
-		utils.RespondJSON(w, http.StatusInternalServerError, map[string]string{
-			"error":  "Failed to fetch data from Gemini",
-			"detail": err.Error(),
+		utils.RespondAPIError(w, http.StatusBadGateway, models.APIError{
+			Code:    models.ErrCodeUpstream,
+			Message: "Failed to fetch data from Gemini",
+			Details: err.Error(),
 		})
 		return
 	}
 
-	err = services.SaveCollegeToCache(stats)
-	if err == nil {
-		collegeData := map[string]interface{}{
-			"id":      stats.CollegeName,
-			"name":    stats.CollegeName,
-			"country": stats.Country,
-			"data":    stats.StudentStatistics,
-		}
-		services.BroadcastNewCollege(stats.Country, collegeData)
+	if cached {
+		go services.CompareAndUpdateCache(stats.CollegeName, *stats)
 	}
 
-	utils.RespondJSON(w, http.StatusOK, stats)
+	utils.SetLastModified(w, stats.UpdatedAt)
+	utils.RespondSuccess(w, http.StatusOK, utils.SelectFields(stats, fields), nil)
 }
 
 func SearchUniversity(w http.ResponseWriter, r *http.Request) {
@@ -57,27 +59,119 @@ func SearchUniversity(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if name == "" {
-		utils.RespondJSON(w, http.StatusBadRequest, map[string]string{"error": "university_name required"})
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, "university_name required")
+		return
+	}
+
+	fields, ok := parseCollegeFields(w, r)
+	if !ok {
 		return
 	}
 
 	result, err := services.SearchUniversityByName(name)
 	if err != nil {
-		utils.RespondJSON(w, http.StatusNotFound, map[string]string{"error": "University not found"})
+		utils.RespondError(w, http.StatusNotFound, models.ErrCodeNotFound, "University not found")
+		return
+	}
+
+	utils.SetLastModified(w, result.UpdatedAt)
+	utils.RespondSuccess(w, http.StatusOK, utils.SelectFields(result, fields), nil)
+}
+
+func SearchColleges(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, "q required")
+		return
+	}
+
+	fields, ok := parseCollegeFields(w, r)
+	if !ok {
+		return
+	}
+
+	page, err := intQueryParam(r, "page", 1, 1, 1000)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
+		return
+	}
+	limit, err := intQueryParam(r, "limit", 10, 1, 50)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	results, err := services.SearchColleges(query, page, limit)
+	if err != nil {
+		log.Printf(" Search error: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, models.ErrCodeInternal, "Search failed")
 		return
 	}
 
-	utils.RespondJSON(w, http.StatusOK, result)
+	utils.RespondSuccess(w, http.StatusOK, utils.SelectFields(results.Results, fields), &models.ResponseMeta{
+		Count: len(results.Results),
+		Limit: results.Limit,
+		Page:  results.Page,
+		Total: results.Total,
+	})
 }
 
 func GetAllColleges(w http.ResponseWriter, r *http.Request) {
-	colleges, err := services.GetAllColleges()
+	opts, err := parseListOptions(r)
 	if err != nil {
-		utils.RespondJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	respondCollegePage(w, opts)
+}
+
+// listQueryParams are the pagination parameters handled by parseListOptions
+var listQueryParams = map[string]bool{"limit": true, "cursor": true, "sort": true, "order": true, "view": true, "fields": true}
+
+// collegeFields are the names ?fields= accepts on endpoints returning colleges
+var collegeFields = utils.JSONFieldNames(models.CollegeStats{}, models.SearchResult{})
+
+func ListColleges(w http.ResponseWriter, r *http.Request) {
+	opts, err := parseListOptions(r)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	known := make(map[string]bool)
+	for _, name := range services.CollegeFilterParams() {
+		known[name] = true
+	}
+
+	params := make(map[string]string)
+	var unknown models.ValidationErrors
+	for name, values := range r.URL.Query() {
+		switch {
+		case listQueryParams[name]:
+		case known[name] && len(values) == 1:
+			params[name] = values[0]
+		case known[name]:
+			unknown = append(unknown, models.FieldError{Field: name, Message: "may only be given once"})
+		default:
+			unknown = append(unknown, models.FieldError{Field: name, Message: "unknown filter"})
+		}
+	}
+
+	filter, errs := services.BuildCollegeFilter(params)
+	errs = append(unknown, errs...)
+	if len(errs) > 0 {
+		utils.RespondAPIError(w, http.StatusBadRequest, models.APIError{
+			Code:    models.ErrCodeValidation,
+			Message: "invalid filters",
+			Fields:  errs,
+			Details: map[string]interface{}{"supported_filters": services.CollegeFilterParams()},
+		})
 		return
 	}
+	opts.Filter = filter
 
-	utils.RespondJSON(w, http.StatusOK, colleges)
+	respondCollegePage(w, opts)
 }
 
 func GetCountries(w http.ResponseWriter, r *http.Request) {
@@ -91,7 +185,7 @@ func GetCountries(w http.ResponseWriter, r *http.Request) {
 			{"id": "4", "name": "Canada"},
 			{"id": "5", "name": "Australia"},
 		}
-		utils.RespondJSON(w, http.StatusOK, defaultCountries)
+		utils.RespondSuccess(w, http.StatusOK, defaultCountries, &models.ResponseMeta{Count: len(defaultCountries)})
 		return
 	}
 
@@ -115,40 +209,141 @@ func GetCountries(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	utils.RespondJSON(w, http.StatusOK, countryList)
+	utils.RespondSuccess(w, http.StatusOK, countryList, &models.ResponseMeta{Count: len(countryList)})
 }
 
 func GetCollegesByCountry(w http.ResponseWriter, r *http.Request) {
 	country := r.URL.Query().Get("country")
 	if country == "" {
-		utils.RespondJSON(w, http.StatusBadRequest, map[string]string{"error": "country parameter required"})
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, "country parameter required")
 		return
 	}
 
-	colleges, err := services.GetCollegesByCountry(country)
+	opts, err := parseListOptions(r)
 	if err != nil {
-		log.Printf(" Error fetching colleges: %v", err)
-		utils.RespondJSON(w, http.StatusInternalServerError, map[string]string{"error": "Failed to fetch colleges"})
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
 		return
 	}
+	opts.Country = country
 
-	collegeList := make([]map[string]interface{}, 0)
-	for _, college := range colleges {
-		collegeList = append(collegeList, map[string]interface{}{
-			"id":      college.CollegeName,
-			"name":    college.CollegeName,
-			"country": country,
-			"data":    college.StudentStatistics,
-		})
+	respondCollegePage(w, opts)
+}
+
+func HealthCheck(w http.ResponseWriter, r *http.Request) {
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"status":   "healthy",
+		"backend":  "Go",
+		"version":  "1.0.0",
+		"realtime": services.RealtimeStats(),
+	}, nil)
+}
+
+// intQueryParam reads an integer query parameter, returning def when it is
+// absent and an error when it is malformed or outside [min, max].
+func intQueryParam(r *http.Request, name string, def, min, max int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return def, nil
 	}
 
-	utils.RespondJSON(w, http.StatusOK, collegeList)
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < min || value > max {
+		return 0, fmt.Errorf("%s must be an integer between %d and %d", name, min, max)
+	}
+	return value, nil
 }
 
-func HealthCheck(w http.ResponseWriter, r *http.Request) {
-	utils.RespondJSON(w, http.StatusOK, map[string]string{
-		"status":  "healthy",
-		"backend": "Go",
-		"version": "1.0.0",
+// parseCollegeFields reads ?fields=, responding with an error when it names
+// unknown fields.
+func parseCollegeFields(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	fields, err := utils.ParseFields(r.URL.Query().Get("fields"), collegeFields)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
+		return nil, false
+	}
+	return fields, true
+}
+
+// parseListOptions reads the limit, cursor, sort, order, view and fields
+// parameters shared by the college list endpoints. sort also accepts a
+// leading "-" for descending order.
+func parseListOptions(r *http.Request) (services.ListOptions, error) {
+	query := r.URL.Query()
+	opts := services.ListOptions{
+		Cursor: query.Get("cursor"),
+		View:   services.ViewSummary,
+	}
+
+	limit, err := intQueryParam(r, "limit", services.DefaultPageLimit, 1, services.MaxPageLimit)
+	if err != nil {
+		return opts, err
+	}
+	opts.Limit = limit
+
+	opts.Sort = query.Get("sort")
+	if strings.HasPrefix(opts.Sort, "-") {
+		opts.Sort = strings.TrimPrefix(opts.Sort, "-")
+		opts.Desc = true
+	}
+	if opts.Sort != "" {
+		if _, ok := services.SortableCollegeFields[opts.Sort]; !ok {
+			return opts, fmt.Errorf("sort must be one of %s", strings.Join(sortedKeys(services.SortableCollegeFields), ", "))
+		}
+	}
+
+	switch query.Get("order") {
+	case "", "asc":
+	case "desc":
+		opts.Desc = true
+	default:
+		return opts, fmt.Errorf("order must be asc or desc")
+	}
+
+	switch view := query.Get("view"); view {
+	case "":
+	case services.ViewSummary, services.ViewFull:
+		opts.View = view
+	default:
+		return opts, fmt.Errorf("view must be summary or full")
+	}
+
+	opts.Fields, err = utils.ParseFields(query.Get("fields"), collegeFields)
+	return opts, err
+}
+
+func respondCollegePage(w http.ResponseWriter, opts services.ListOptions) {
+	page, err := services.ListColleges(opts)
+	if errors.Is(err, services.ErrInvalidCursor) {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf(" Error listing colleges: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, models.ErrCodeInternal, "Failed to fetch colleges")
+		return
+	}
+
+	var colleges interface{} = page.Colleges
+	if opts.View != services.ViewFull && len(opts.Fields) == 0 {
+		summaries := make([]models.CollegeListItem, 0, len(page.Colleges))
+		for _, college := range page.Colleges {
+			summaries = append(summaries, college.ToListItem())
+		}
+		colleges = summaries
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, utils.SelectFields(colleges, opts.Fields), &models.ResponseMeta{
+		Count:      len(page.Colleges),
+		Limit:      opts.Limit,
+		NextCursor: page.NextCursor,
 	})
 }
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
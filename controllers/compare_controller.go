@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"net/http"
+
+	"gobackend/models"
+	"gobackend/services"
+	"gobackend/utils"
+)
+
+// Number of colleges /api/compare accepts
+const (
+	minCompareColleges = 2
+	maxCompareColleges = 3
+)
+
+func CompareColleges(w http.ResponseWriter, r *http.Request) {
+	names, err := services.ParseCompareNames(r.URL.Query().Get("names"), minCompareColleges, maxCompareColleges)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	colleges, failures := services.ResolveColleges(names)
+	if len(failures) > 0 {
+		utils.RespondAPIError(w, http.StatusUnprocessableEntity, models.APIError{
+			Code:    models.ErrCodeValidation,
+			Message: "could not resolve every college",
+			Details: map[string]interface{}{"failures": failures},
+		})
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, services.CompareColleges(colleges), nil)
+}
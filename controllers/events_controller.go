@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gobackend/models"
+	"gobackend/services"
+	"gobackend/utils"
+)
+
+var errInvalidSince = errors.New("since must be an event sequence number")
+
+const (
+	// sseHeartbeat keeps proxies from closing idle event streams
+	sseHeartbeat = 15 * time.Second
+	// sseRetry is how long browsers wait before reconnecting
+	sseRetry = 3 * time.Second
+)
+
+// StreamEvents serves college and country events as Server-Sent Events for
+// clients that cannot use WebSockets. ?topics= takes a comma-separated list
+// of the topics the WebSocket subscribe command accepts, and ?country= is
+// shorthand for country:<name>. With neither, every college event is sent.
+// Reconnecting clients send Last-Event-ID (or ?last_event_id=) to receive the
+// events they missed.
+func StreamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, models.ErrCodeInternal, "streaming is not supported")
+		return
+	}
+
+	topics, err := parseEventTopics(r)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeValidation, err.Error())
+		return
+	}
+	if len(topics) == 0 {
+		topics[services.GlobalTopic] = true
+	}
+
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = r.URL.Query().Get("last_event_id")
+	}
+	var since uint64
+	if lastID != "" {
+		if since, err = strconv.ParseUint(lastID, 10, 64); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, "Last-Event-ID must be an event id")
+			return
+		}
+	}
+
+	release, ok := admitRealtime(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+
+	client := services.NewSubscriber("sse " + r.RemoteAddr)
+	defer client.Close()
+	defer services.UnregisterClient(client)
+
+	var missed []services.Event
+	resync := false
+	if lastID != "" {
+		var ok bool
+		missed, ok, err = services.SubscribeSince(client, topics, since)
+		resync = !ok
+	} else {
+		err = subscribeTopics(client, topics)
+	}
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeValidation, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", sseRetry.Milliseconds())
+
+	if resync {
+		payload, _ := json.Marshal(resyncMessage())
+		writeSSE(w, services.Event{Type: models.WsResync, Payload: payload})
+	}
+	for _, event := range missed {
+		writeSSE(w, event)
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-client.Done():
+			return
+		case event := <-client.Events():
+			writeSSE(w, event)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// parseEventTopics reads ?topics= and ?country= into canonical topics
+func parseEventTopics(r *http.Request) (map[string]bool, error) {
+	var raw []string
+	for _, value := range r.URL.Query()["topics"] {
+		raw = append(raw, strings.Split(value, ",")...)
+	}
+	if country := strings.TrimSpace(r.URL.Query().Get("country")); country != "" {
+		raw = append(raw, "country:"+country)
+	}
+
+	topics := make(map[string]bool)
+	for _, value := range raw {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		topic, err := services.ParseTopic(value)
+		if err != nil {
+			return nil, err
+		}
+		topics[topic] = true
+	}
+
+	if len(topics) > services.MaxClientTopics {
+		return nil, fmt.Errorf("at most %d topics may be requested", services.MaxClientTopics)
+	}
+	return topics, nil
+}
+
+// resyncMessage tells a client its missed events are gone; seq is where to
+// resume from once it has reloaded
+func resyncMessage() models.WebSocketMessage {
+	return models.WebSocketMessage{
+		Type:    models.WsResync,
+		Data:    map[string]interface{}{"seq": services.LatestEventSeq()},
+		Message: "missed events are no longer available; reload your data",
+	}
+}
+
+// writeSSE writes one event; JSON payloads never contain newlines, so each
+// fits on a single data line
+func writeSSE(w http.ResponseWriter, event services.Event) {
+	if event.Seq != 0 {
+		fmt.Fprintf(w, "id: %d\n", event.Seq)
+	}
+	if event.Type != "" {
+		fmt.Fprintf(w, "event: %s\n", event.Type)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", event.Payload)
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"gobackend/gql"
+	"gobackend/utils"
+)
+
+// maxGraphQLBody caps the size of a POSTed GraphQL request
+const maxGraphQLBody = 1 << 20
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// GraphQL serves queries sent as GET parameters, a JSON POST body, or a raw
+// application/graphql POST body.
+func GraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				respondGraphQLError(w, "variables must be a JSON object")
+				return
+			}
+		}
+	} else {
+		body := http.MaxBytesReader(w, r.Body, maxGraphQLBody)
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/graphql") {
+			raw, err := io.ReadAll(body)
+			if err != nil {
+				respondGraphQLError(w, "could not read body")
+				return
+			}
+			req.Query = string(raw)
+		} else if err := json.NewDecoder(body).Decode(&req); err != nil {
+			respondGraphQLError(w, "invalid JSON body: "+err.Error())
+			return
+		}
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		respondGraphQLError(w, "query required")
+		return
+	}
+
+	result := gql.Execute(r.Context(), req.Query, req.Variables, req.OperationName)
+
+	// A document that fails to parse or validate produces no data at all
+	status := http.StatusOK
+	if result.HasErrors() && result.Data == nil {
+		status = http.StatusBadRequest
+	}
+	utils.RespondJSON(w, status, result)
+}
+
+// respondGraphQLError reports a malformed request in the GraphQL response
+// shape rather than the REST envelope.
+func respondGraphQLError(w http.ResponseWriter, message string) {
+	utils.RespondJSON(w, http.StatusBadRequest, map[string]interface{}{
+		"errors": []map[string]string{{"message": message}},
+	})
+}
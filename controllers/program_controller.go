@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+
+	"gobackend/models"
+	"gobackend/services"
+	"gobackend/utils"
+)
+
+func ListPrograms(w http.ResponseWriter, r *http.Request) {
+	query, err := parseProgramQuery(r, 0)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	programs := services.ListPrograms(query)
+	utils.RespondSuccess(w, http.StatusOK, programs, &models.ResponseMeta{Count: len(programs), Limit: query.Limit})
+}
+
+func SearchPrograms(w http.ResponseWriter, r *http.Request) {
+	query, err := parseProgramQuery(r, services.DefaultPageLimit)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
+		return
+	}
+	if strings.TrimSpace(query.Program) == "" {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, "program parameter is required")
+		return
+	}
+
+	matches := services.SearchCollegesByProgram(query)
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"program":  services.NormalizeProgram(query.Program, query.Level),
+		"colleges": matches,
+	}, &models.ResponseMeta{Count: len(matches), Limit: query.Limit})
+}
+
+// parseProgramQuery reads the program, level, country, max_fee and limit
+// parameters shared by the program endpoints. A zero defaultLimit means no
+// limit unless one is given.
+func parseProgramQuery(r *http.Request, defaultLimit int) (services.ProgramQuery, error) {
+	params := r.URL.Query()
+	query := services.ProgramQuery{
+		Program: params.Get("program"),
+		Level:   strings.ToLower(params.Get("level")),
+		Country: params.Get("country"),
+	}
+
+	switch query.Level {
+	case "", models.LevelUG, models.LevelPG, models.LevelPhD:
+	default:
+		return query, errors.New("level must be ug, pg or phd")
+	}
+
+	var err error
+	if query.MaxFee, err = intQueryParam(r, "max_fee", 0, 0, math.MaxInt32); err != nil {
+		return query, err
+	}
+	if query.Limit, err = intQueryParam(r, "limit", defaultLimit, 1, 1000); err != nil {
+		return query, err
+	}
+	return query, nil
+}
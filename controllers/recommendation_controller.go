@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"gobackend/models"
+	"gobackend/services"
+	"gobackend/utils"
+)
+
+func RecommendColleges(w http.ResponseWriter, r *http.Request) {
+	var req models.RecommendationRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		respondInvalidBody(w, err)
+		return
+	}
+
+	if errs := req.Validate(); len(errs) > 0 {
+		utils.RespondAPIError(w, http.StatusUnprocessableEntity, models.APIError{
+			Code:    models.ErrCodeValidation,
+			Message: "validation failed",
+			Fields:  errs,
+		})
+		return
+	}
+
+	recommendations, err := services.RecommendColleges(req)
+	if err != nil {
+		log.Printf("❌ Recommendation failed: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, models.ErrCodeInternal, "Failed to compute recommendations")
+		return
+	}
+
+	utils.RespondSuccess(w, http.StatusOK, recommendations, nil)
+}
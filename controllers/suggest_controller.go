@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"gobackend/models"
+	"gobackend/services"
+	"gobackend/utils"
+)
+
+func SuggestColleges(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" || len(query) > 200 {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, "q must be between 1 and 200 characters")
+		return
+	}
+
+	limit, err := intQueryParam(r, "limit", services.DefaultSuggestLimit, 1, services.MaxSuggestLimit)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	start := time.Now()
+	suggestions := services.SuggestColleges(query, limit)
+
+	// Timing goes in a header so identical suggestions keep the same ETag
+	w.Header().Set("Server-Timing", fmt.Sprintf("suggest;dur=%.3f", float64(time.Since(start).Microseconds())/1000))
+	utils.RespondSuccess(w, http.StatusOK, map[string]interface{}{
+		"query":       query,
+		"suggestions": suggestions,
+	}, &models.ResponseMeta{Count: len(suggestions), Limit: limit})
+}
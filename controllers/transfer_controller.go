@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"gobackend/models"
+	"gobackend/services"
+	"gobackend/utils"
+)
+
+// maxImportSize caps the request body accepted by ImportColleges
+const maxImportSize = 50 << 20
+
+func ImportColleges(w http.ResponseWriter, r *http.Request) {
+	format := transferFormat(r, r.Header.Get("Content-Type"))
+	if format == "" {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, "format must be jsonl or csv")
+		return
+	}
+
+	report, err := services.ImportColleges(http.MaxBytesReader(w, r.Body, maxImportSize), format)
+	if err != nil {
+		log.Printf("❌ Import failed: %v", err)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.RespondError(w, http.StatusRequestEntityTooLarge, models.ErrCodeTooLarge, fmt.Sprintf("import body exceeds %d bytes", tooLarge.Limit))
+			return
+		}
+		utils.RespondAPIError(w, http.StatusBadRequest, models.APIError{
+			Code:    models.ErrCodeBadRequest,
+			Message: err.Error(),
+			Details: report,
+		})
+		return
+	}
+
+	status := http.StatusOK
+	if report.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	utils.RespondSuccess(w, status, report, nil)
+}
+
+func ExportColleges(w http.ResponseWriter, r *http.Request) {
+	format := transferFormat(r, r.Header.Get("Accept"))
+	if format == "" {
+		if r.URL.Query().Get("format") != "" {
+			utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, "format must be jsonl or csv")
+			return
+		}
+		format = services.FormatJSONL
+	}
+
+	query := r.URL.Query()
+	filter := services.ExportFilter{
+		Country:        query.Get("country"),
+		Name:           query.Get("name"),
+		IncludeDeleted: query.Get("include_deleted") == "true",
+	}
+	if since := query.Get("updated_since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, "updated_since must be an RFC 3339 timestamp")
+			return
+		}
+		filter.UpdatedSince = t
+	}
+
+	contentType := "application/x-ndjson"
+	if format == services.FormatCSV {
+		contentType = "text/csv; charset=utf-8"
+	}
+	out := &exportWriter{
+		ResponseWriter: w,
+		contentType:    contentType,
+		filename:       fmt.Sprintf("colleges-%s.%s", time.Now().UTC().Format("20060102-150405"), format),
+	}
+
+	count, err := services.ExportColleges(r.Context(), out, format, filter)
+	if err != nil && !out.started {
+		log.Printf("❌ Export failed: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, models.ErrCodeInternal, "Failed to export colleges")
+		return
+	}
+	if err != nil {
+		// Part of the export has been sent, so all we can do is log and stop.
+		log.Printf("❌ Export failed after %d colleges: %v", count, err)
+		return
+	}
+	out.start()
+	log.Printf("📤 Exported %d colleges as %s", count, format)
+}
+
+// exportWriter sends the download headers with the first bytes of an export,
+// so an export that fails before writing anything can still answer with an
+// error envelope
+type exportWriter struct {
+	http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (e *exportWriter) start() {
+	if e.started {
+		return
+	}
+	e.started = true
+	e.Header().Set("Content-Type", e.contentType)
+	e.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, e.filename))
+	e.WriteHeader(http.StatusOK)
+}
+
+func (e *exportWriter) Write(p []byte) (int, error) {
+	e.start()
+	return e.ResponseWriter.Write(p)
+}
+
+// Flush sends what has been written so far; before the first write there is
+// nothing to send
+func (e *exportWriter) Flush() {
+	if flusher, ok := e.ResponseWriter.(http.Flusher); ok && e.started {
+		flusher.Flush()
+	}
+}
+
+// transferFormat picks jsonl or csv from the format query parameter, falling
+// back to the given media type header. It returns "" when neither matches.
+func transferFormat(r *http.Request, mediaType string) string {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	switch format {
+	case services.FormatJSONL, "ndjson":
+		return services.FormatJSONL
+	case services.FormatCSV:
+		return services.FormatCSV
+	case "":
+	default:
+		return ""
+	}
+
+	switch {
+	case strings.Contains(mediaType, "text/csv"):
+		return services.FormatCSV
+	case strings.Contains(mediaType, "ndjson"), strings.Contains(mediaType, "jsonl"):
+		return services.FormatJSONL
+	}
+	return ""
+}
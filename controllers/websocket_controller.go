@@ -1,126 +1,215 @@
 package controllers
 
 import (
+	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
-	"time"
+	"strconv"
+	"strings"
 
+	"gobackend/models"
 	"gobackend/services"
+	"gobackend/utils"
 
 	"github.com/gorilla/websocket"
 )
 
+// upgrader negotiates permessage-deflate with clients that offer it, and the
+// MessagePack subprotocol with clients that ask for it
 var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
+	ReadBufferSize:    1024,
+	WriteBufferSize:   1024,
+	CheckOrigin:       services.CheckOrigin,
+	EnableCompression: true,
+	Subprotocols:      services.WebSocketSubprotocols,
+	Error:             rejectHandshake,
 }
 
+// rejectHandshake answers a failed WebSocket handshake, such as a disallowed
+// origin, with the error envelope the other handlers use
+func rejectHandshake(w http.ResponseWriter, r *http.Request, status int, reason error) {
+	code := models.ErrCodeBadRequest
+	switch status {
+	case http.StatusForbidden:
+		code = models.ErrCodeForbidden
+	case http.StatusInternalServerError:
+		code = models.ErrCodeInternal
+	}
+	utils.RespondError(w, status, code, reason.Error())
+}
+
+// HandleWebSocketColleges streams college events. ?country= and ?topics=
+// subscribe on connect; further subscribe/unsubscribe commands can follow
+// countries, single colleges or the global topic over the same socket.
+// Reconnecting clients pass ?since=<seq> to receive only what they missed.
 func HandleWebSocketColleges(w http.ResponseWriter, r *http.Request) {
-	country := r.URL.Query().Get("country")
-	if country == "" {
-		http.Error(w, "country parameter required", http.StatusBadRequest)
+	country := strings.TrimSpace(r.URL.Query().Get("country"))
+	topics, err := parseEventTopics(r)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeValidation, err.Error())
+		return
+	}
+	since, resume, err := parseSince(r)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
 		return
 	}
 
+	release, ok := admitRealtime(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Printf("❌ WebSocket upgrade error: %v", err)
 		return
 	}
-	defer conn.Close()
-
-	log.Printf("🔌 WebSocket client connected for country: %s", country)
-
-	// Set connection parameters for stability
-	conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-	conn.SetPongHandler(func(string) error {
-		conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-		return nil
-	})
-
-	services.RegisterClient(country, conn)
-	services.SendCollegesUpdate(country, conn)
 
-	// Heartbeat ticker
-	ticker := time.NewTicker(25 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			// Send ping to keep connection alive
-			if err := conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(10*time.Second)); err != nil {
-				log.Printf("❌ WebSocket ping error for %s: %v", country, err)
-				goto disconnect
-			}
+	label := country
+	if label == "" {
+		label = r.RemoteAddr
+	}
+	log.Printf("🔌 WebSocket client connected for colleges: %s", label)
 
-		default:
-			// Read message with timeout
-			conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-			_, _, err := conn.ReadMessage()
-			if err != nil {
-				if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure, websocket.CloseNoStatusReceived) {
-					log.Printf("❌ WebSocket error for %s: %v", country, err)
-				}
-				goto disconnect
+	client := services.NewWsClient(conn, label)
+	err = startWsClient(client, topics, since, resume, func() {
+		if country != "" {
+			services.SendCollegesUpdate(country, client)
+		}
+		for topic := range topics {
+			if name, ok := strings.CutPrefix(topic, "country:"); ok && !strings.EqualFold(name, country) {
+				services.SendCollegesUpdate(name, client)
 			}
 		}
+	})
+	if err != nil {
+		services.UnregisterClient(client)
+		log.Printf("🔌 WebSocket client rejected for colleges: %s: %v", label, err)
+		return
 	}
 
-disconnect:
-	services.UnregisterClient(country, conn)
-	log.Printf("🔌 WebSocket client disconnected for country: %s", country)
+	client.ReadPump(func(payload []byte) {
+		services.HandleClientMessage(client, payload)
+	})
+
+	services.UnregisterClient(client)
+	log.Printf("🔌 WebSocket client disconnected for colleges: %s", label)
 }
 
+// HandleWebSocketCountries sends the country list and then keeps the client
+// subscribed to country_added, country_removed and country_count_changed.
+// ?since=<seq> replays missed country events instead of resending the list.
 func HandleWebSocketCountries(w http.ResponseWriter, r *http.Request) {
+	since, resume, err := parseSince(r)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, models.ErrCodeBadRequest, err.Error())
+		return
+	}
+
+	release, ok := admitRealtime(w, r)
+	if !ok {
+		return
+	}
+	defer release()
+
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Printf("❌ WebSocket upgrade error: %v", err)
 		return
 	}
-	defer conn.Close()
 
 	log.Printf("🔌 WebSocket client connected for countries updates")
 
-	// Set connection parameters for stability
-	conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-	conn.SetPongHandler(func(string) error {
-		conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-		return nil
+	client := services.NewWsClient(conn, "countries")
+	err = startWsClient(client, map[string]bool{services.CountriesTopic: true}, since, resume, func() {
+		services.SendCountriesUpdate(client)
 	})
+	if err != nil {
+		services.UnregisterClient(client)
+		log.Printf("🔌 WebSocket client rejected for countries: %v", err)
+		return
+	}
 
-	// Send initial countries list
-	services.SendCountriesUpdate(conn)
-
-	// Heartbeat ticker
-	ticker := time.NewTicker(25 * time.Second)
-	defer ticker.Stop()
-
-	// Keep connection open for updates
-	for {
-		select {
-		case <-ticker.C:
-			// Send ping to keep connection alive
-			if err := conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(10*time.Second)); err != nil {
-				log.Printf("❌ WebSocket ping error for countries: %v", err)
-				goto disconnect2
-			}
+	client.ReadPump(func(payload []byte) {
+		services.HandleClientMessage(client, payload)
+	})
 
-		default:
-			conn.SetReadDeadline(time.Now().Add(60 * time.Second))
-			_, _, err := conn.ReadMessage()
-			if err != nil {
-				if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure, websocket.CloseNoStatusReceived) {
-					log.Printf("❌ WebSocket error for countries: %v", err)
-				}
-				goto disconnect2
-			}
+	services.UnregisterClient(client)
+	log.Printf("🔌 WebSocket client disconnected for countries")
+}
+
+// startWsClient subscribes client to topics and starts its write pump. When
+// resuming, the events missed after since are written first; if they are no
+// longer available the client gets a resync message and the snapshot, as a
+// new connection does. If client may not follow every topic it is sent a
+// validation error and closed, and the error is returned.
+func startWsClient(client *services.Subscriber, topics map[string]bool, since uint64, resume bool, snapshot func()) error {
+	var missed []services.Event
+	ok := false
+	var err error
+	if resume {
+		missed, ok, err = services.SubscribeSince(client, topics, since)
+	} else {
+		err = subscribeTopics(client, topics)
+	}
+	if err != nil {
+		payload, _ := json.Marshal(models.WebSocketMessage{Type: models.WsError, Code: models.ErrCodeValidation, Message: err.Error()})
+		go client.WritePump([]services.Event{{Type: models.WsError, Payload: payload}})
+		client.Close()
+		return err
+	}
+
+	go client.WritePump(missed)
+	if ok {
+		return nil
+	}
+	if resume {
+		client.Send(resyncMessage())
+	}
+	snapshot()
+	return nil
+}
+
+// subscribeTopics subscribes client to every topic in topics
+func subscribeTopics(client *services.Subscriber, topics map[string]bool) error {
+	for topic := range topics {
+		if err := services.Subscribe(client, topic); err != nil {
+			return err
 		}
 	}
+	return nil
+}
+
+// admitRealtime reserves a connection slot for a WebSocket or SSE client,
+// answering 503 when the server is full and 429 when the client's address
+// already has WS_MAX_CONNECTIONS_PER_IP connections
+func admitRealtime(w http.ResponseWriter, r *http.Request) (release func(), ok bool) {
+	release, err := services.AcquireConnection(r)
+	switch {
+	case errors.Is(err, services.ErrTooManyFromAddress):
+		w.Header().Set("Retry-After", "30")
+		utils.RespondError(w, http.StatusTooManyRequests, models.ErrCodeRateLimited, err.Error())
+		return nil, false
+	case err != nil:
+		w.Header().Set("Retry-After", "30")
+		utils.RespondError(w, http.StatusServiceUnavailable, models.ErrCodeUnavailable, err.Error())
+		return nil, false
+	}
+	return release, true
+}
 
-disconnect2:
-	log.Printf("🔌 WebSocket client disconnected for countries")
+// parseSince reads ?since=<seq>; resume is false when it is absent
+func parseSince(r *http.Request) (since uint64, resume bool, err error) {
+	raw := r.URL.Query().Get("since")
+	if raw == "" {
+		return 0, false, nil
+	}
+	since, err = strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, false, errInvalidSince
+	}
+	return since, true, nil
 }
@@ -0,0 +1,249 @@
+// Package gql exposes college data as a GraphQL schema whose resolvers call
+// the same service functions as the REST controllers.
+package gql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gobackend/models"
+	"gobackend/services"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Schema is built once at startup by InitializeSchema
+var Schema graphql.Schema
+
+var countryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Country",
+	Fields: graphql.Fields{
+		"name": &graphql.Field{Type: graphql.String},
+		"college_count": &graphql.Field{
+			Type: graphql.Int,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				analytics, _, err := services.GetAnalyticsForCountry(p.Source.(country).Name)
+				if errors.Is(err, services.ErrCountryNotFound) {
+					return 0, nil
+				}
+				if err != nil {
+					return nil, err
+				}
+				return analytics.Colleges, nil
+			},
+		},
+		"colleges": &graphql.Field{
+			Type: collegePageType,
+			Args: pageArgs(),
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return resolveCollegePage(p, p.Source.(country).Name, nil)
+			},
+		},
+	},
+})
+
+// country is the source value for the Country type
+type country struct {
+	Name string `json:"name"`
+}
+
+// InitializeSchema builds Schema. It fails only if the type definitions are
+// inconsistent, which is a programming error.
+func InitializeSchema() error {
+	collegeFilterType := collegeFilterInput()
+
+	collegesArgs := pageArgs()
+	collegesArgs["country"] = &graphql.ArgumentConfig{Type: graphql.String}
+	collegesArgs["filter"] = &graphql.ArgumentConfig{Type: collegeFilterType}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"college": &graphql.Field{
+				Type:        collegeType,
+				Description: "A college by id, or by name (fetched from Gemini when not stored yet)",
+				Args: graphql.FieldConfigArgument{
+					"id":   &graphql.ArgumentConfig{Type: graphql.ID},
+					"name": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: resolveCollege,
+			},
+			"colleges": &graphql.Field{
+				Type: collegePageType,
+				Args: collegesArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					name, _ := p.Args["country"].(string)
+					filter, _ := p.Args["filter"].(map[string]interface{})
+					return resolveCollegePage(p, name, filter)
+				},
+			},
+			"countries": &graphql.Field{
+				Type:    graphql.NewList(countryType),
+				Resolve: resolveCountries,
+			},
+			"country": &graphql.Field{
+				Type: countryType,
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return country{Name: p.Args["name"].(string)}, nil
+				},
+			},
+			"search": &graphql.Field{
+				Type:        searchResponseType,
+				Description: "Relevance-ranked full-text search",
+				Args: graphql.FieldConfigArgument{
+					"q":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"page":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+					"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+				},
+				Resolve: resolveSearch,
+			},
+		},
+	})
+
+	var err error
+	Schema, err = graphql.NewSchema(graphql.SchemaConfig{Query: query})
+	return err
+}
+
+// Execute runs a GraphQL request against Schema
+func Execute(ctx context.Context, query string, variables map[string]interface{}, operationName string) *graphql.Result {
+	return graphql.Do(graphql.Params{
+		Schema:         Schema,
+		RequestString:  query,
+		VariableValues: variables,
+		OperationName:  operationName,
+		Context:        ctx,
+	})
+}
+
+func pageArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"limit":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: services.DefaultPageLimit},
+		"cursor": &graphql.ArgumentConfig{Type: graphql.String},
+		"sort":   &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "college_name"},
+		"desc":   &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+	}
+}
+
+// collegeFilterInput has one field per filter accepted by GET /api/colleges
+func collegeFilterInput() *graphql.InputObject {
+	fields := graphql.InputObjectConfigFieldMap{}
+	for _, name := range services.CollegeFilterParams() {
+		if name == "country" {
+			continue // a top-level argument of colleges
+		}
+		fieldType := graphql.Int
+		if services.IsTextCollegeFilter(name) {
+			fieldType = graphql.String
+		}
+		fields[name] = &graphql.InputObjectFieldConfig{Type: fieldType}
+	}
+	return graphql.NewInputObject(graphql.InputObjectConfig{Name: "CollegeFilter", Fields: fields})
+}
+
+func resolveCollege(p graphql.ResolveParams) (interface{}, error) {
+	id, _ := p.Args["id"].(string)
+	name, _ := p.Args["name"].(string)
+
+	switch {
+	case id != "" && name != "":
+		return nil, errors.New("pass either id or name, not both")
+	case id != "":
+		college, err := services.GetCollegeByID(id, false)
+		if errors.Is(err, services.ErrCollegeNotFound) {
+			return nil, nil
+		}
+		return college, err
+	case strings.TrimSpace(name) != "":
+		college, _, err := services.ResolveCollege(strings.TrimSpace(name))
+		if errors.Is(err, services.ErrCollegeDeleted) {
+			return nil, nil
+		}
+		return college, err
+	}
+	return nil, errors.New("id or name is required")
+}
+
+func resolveCollegePage(p graphql.ResolveParams, countryName string, filterArgs map[string]interface{}) (interface{}, error) {
+	limit := p.Args["limit"].(int)
+	if limit < 1 || limit > services.MaxPageLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", services.MaxPageLimit)
+	}
+
+	opts := services.ListOptions{
+		Country: countryName,
+		Sort:    p.Args["sort"].(string),
+		Desc:    p.Args["desc"].(bool),
+		Limit:   limit,
+		View:    services.ViewFull,
+	}
+	opts.Cursor, _ = p.Args["cursor"].(string)
+
+	if len(filterArgs) > 0 {
+		params := make(map[string]string, len(filterArgs))
+		for name, value := range filterArgs {
+			params[name] = fmt.Sprint(value)
+		}
+		filter, errs := services.BuildCollegeFilter(params)
+		if len(errs) > 0 {
+			return nil, errs
+		}
+		opts.Filter = filter
+	}
+
+	page, err := services.ListColleges(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	colleges := make([]*models.CollegeStats, len(page.Colleges))
+	for i := range page.Colleges {
+		colleges[i] = &page.Colleges[i]
+	}
+	return map[string]interface{}{
+		"colleges":    colleges,
+		"count":       len(colleges),
+		"next_cursor": page.NextCursor,
+	}, nil
+}
+
+func resolveCountries(p graphql.ResolveParams) (interface{}, error) {
+	names, err := services.GetDistinctCountries()
+	if err != nil {
+		return nil, err
+	}
+
+	countries := make([]country, 0, len(names))
+	for _, name := range names {
+		if s, ok := name.(string); ok && s != "" {
+			countries = append(countries, country{Name: s})
+		}
+	}
+	return countries, nil
+}
+
+func resolveSearch(p graphql.ResolveParams) (interface{}, error) {
+	query := strings.TrimSpace(p.Args["q"].(string))
+	if query == "" {
+		return nil, errors.New("q must not be empty")
+	}
+
+	page, limit := p.Args["page"].(int), p.Args["limit"].(int)
+	if page < 1 || page > 1000 {
+		return nil, errors.New("page must be between 1 and 1000")
+	}
+	if limit < 1 || limit > 50 {
+		return nil, errors.New("limit must be between 1 and 50")
+	}
+
+	results, err := services.SearchColleges(query, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,135 @@
+package gql
+
+import (
+	"time"
+
+	"gobackend/models"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// jsonScalar passes statistic values through unchanged; Gemini returns
+// numbers, strings and the occasional object for them.
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "Any JSON value",
+	Serialize:   func(value interface{}) interface{} { return value },
+	ParseValue:  func(value interface{}) interface{} { return value },
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return valueAST.GetValue()
+	},
+})
+
+var feesType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Fees",
+	Description: "Yearly fees per study level",
+	Fields: graphql.Fields{
+		"ug_yearly_min":  &graphql.Field{Type: graphql.Int},
+		"ug_yearly_max":  &graphql.Field{Type: graphql.Int},
+		"pg_yearly_min":  &graphql.Field{Type: graphql.Int},
+		"pg_yearly_max":  &graphql.Field{Type: graphql.Int},
+		"phd_yearly_min": &graphql.Field{Type: graphql.Int},
+		"phd_yearly_max": &graphql.Field{Type: graphql.Int},
+	},
+})
+
+var genderRatioType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "GenderRatio",
+	Fields: graphql.Fields{
+		"male_percentage":   &graphql.Field{Type: graphql.Int},
+		"female_percentage": &graphql.Field{Type: graphql.Int},
+	},
+})
+
+var statisticType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Statistic",
+	Fields: graphql.Fields{
+		"category": &graphql.Field{Type: graphql.String},
+		"value":    &graphql.Field{Type: jsonScalar},
+	},
+})
+
+// collegeType mirrors models.CollegeStats; field names follow its JSON tags
+var collegeType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "College",
+	Fields: graphql.Fields{
+		"id": &graphql.Field{
+			Type: graphql.ID,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				if college, ok := p.Source.(*models.CollegeStats); ok && !college.ID.IsZero() {
+					return college.ID.Hex(), nil
+				}
+				return nil, nil
+			},
+		},
+		"college_name":           &graphql.Field{Type: graphql.String},
+		"aliases":                &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"country":                &graphql.Field{Type: graphql.String},
+		"about":                  &graphql.Field{Type: graphql.String},
+		"location":               &graphql.Field{Type: graphql.String},
+		"summary":                &graphql.Field{Type: graphql.String},
+		"ug_programs":            &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"pg_programs":            &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"phd_programs":           &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"fees":                   &graphql.Field{Type: feesType},
+		"scholarships":           &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"student_gender_ratio":   &graphql.Field{Type: genderRatioType},
+		"faculty_staff":          &graphql.Field{Type: graphql.Int},
+		"international_students": &graphql.Field{Type: graphql.Int},
+		"global_ranking":         &graphql.Field{Type: graphql.String},
+		"global_ranking_value":   &graphql.Field{Type: graphql.Int},
+		"departments":            &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"student_statistics":     &graphql.Field{Type: graphql.NewList(statisticType)},
+		"additional_details":     &graphql.Field{Type: graphql.NewList(statisticType)},
+		"sources":                &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"manually_edited":        &graphql.Field{Type: graphql.Boolean},
+		"created_at":             &graphql.Field{Type: graphql.DateTime, Resolve: collegeTime(func(c *models.CollegeStats) time.Time { return c.CreatedAt })},
+		"updated_at":             &graphql.Field{Type: graphql.DateTime, Resolve: collegeTime(func(c *models.CollegeStats) time.Time { return c.UpdatedAt })},
+	},
+})
+
+// collegeTime resolves a timestamp, returning null for unset ones
+func collegeTime(get func(*models.CollegeStats) time.Time) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		college, ok := p.Source.(*models.CollegeStats)
+		if !ok || get(college).IsZero() {
+			return nil, nil
+		}
+		return get(college), nil
+	}
+}
+
+var collegePageType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "CollegePage",
+	Description: "One page of colleges; pass next_cursor back as cursor for the next page",
+	Fields: graphql.Fields{
+		"colleges":    &graphql.Field{Type: graphql.NewList(collegeType)},
+		"count":       &graphql.Field{Type: graphql.Int},
+		"next_cursor": &graphql.Field{Type: graphql.String},
+	},
+})
+
+var searchResultType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SearchResult",
+	Fields: graphql.Fields{
+		"id":             &graphql.Field{Type: graphql.ID},
+		"college_name":   &graphql.Field{Type: graphql.String},
+		"country":        &graphql.Field{Type: graphql.String},
+		"location":       &graphql.Field{Type: graphql.String},
+		"global_ranking": &graphql.Field{Type: graphql.String},
+		"score":          &graphql.Field{Type: graphql.Float},
+		"highlights":     &graphql.Field{Type: jsonScalar},
+	},
+})
+
+var searchResponseType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SearchResponse",
+	Fields: graphql.Fields{
+		"query":   &graphql.Field{Type: graphql.String},
+		"results": &graphql.Field{Type: graphql.NewList(searchResultType)},
+		"page":    &graphql.Field{Type: graphql.Int},
+		"limit":   &graphql.Field{Type: graphql.Int},
+		"total":   &graphql.Field{Type: graphql.Int},
+	},
+})
@@ -0,0 +1,88 @@
+package grpcapi
+
+import (
+	"encoding/json"
+
+	"gobackend/models"
+	"gobackend/proto/collegepb"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProtoCollege(c *models.CollegeStats) *collegepb.College {
+	college := &collegepb.College{
+		CollegeName:  c.CollegeName,
+		Aliases:      c.Aliases,
+		Country:      c.Country,
+		About:        c.About,
+		Location:     c.Location,
+		Summary:      c.Summary,
+		UgPrograms:   c.UGPrograms,
+		PgPrograms:   c.PGPrograms,
+		PhdPrograms:  c.PhDPrograms,
+		Scholarships: c.Scholarships,
+		Fees: &collegepb.Fees{
+			UgYearlyMin:  int32(c.Fees.UGYearlyMin),
+			UgYearlyMax:  int32(c.Fees.UGYearlyMax),
+			PgYearlyMin:  int32(c.Fees.PGYearlyMin),
+			PgYearlyMax:  int32(c.Fees.PGYearlyMax),
+			PhdYearlyMin: int32(c.Fees.PhDYearlyMin),
+			PhdYearlyMax: int32(c.Fees.PhDYearlyMax),
+		},
+		StudentGenderRatio: &collegepb.GenderRatio{
+			MalePercentage:   int32(c.StudentGenderRatio.MalePercentage),
+			FemalePercentage: int32(c.StudentGenderRatio.FemalePercentage),
+		},
+		FacultyStaff:          int32(c.FacultyStaff),
+		InternationalStudents: int32(c.InternationalStudents),
+		GlobalRanking:         c.GlobalRanking,
+		GlobalRankingValue:    int32(c.GlobalRankingValue),
+		Departments:           c.Departments,
+		StudentStatistics:     toProtoStatistics(c.StudentStatistics),
+		AdditionalDetails:     toProtoStatistics(c.AdditionalDetails),
+		Sources:               c.Sources,
+		ManuallyEdited:        c.ManuallyEdited,
+	}
+
+	if !c.ID.IsZero() {
+		college.Id = c.ID.Hex()
+	}
+	if !c.CreatedAt.IsZero() {
+		college.CreatedAt = timestamppb.New(c.CreatedAt)
+	}
+	if !c.UpdatedAt.IsZero() {
+		college.UpdatedAt = timestamppb.New(c.UpdatedAt)
+	}
+	return college
+}
+
+func toProtoStatistics(items []models.StatisticItem) []*collegepb.Statistic {
+	stats := make([]*collegepb.Statistic, 0, len(items))
+	for _, item := range items {
+		stats = append(stats, &collegepb.Statistic{Category: item.Category, Value: toProtoValue(item.Value)})
+	}
+	return stats
+}
+
+// toProtoValue converts a statistic value. Values decoded from BSON can be
+// driver types structpb doesn't know, so those go through JSON first.
+func toProtoValue(v interface{}) *structpb.Value {
+	if value, err := structpb.NewValue(v); err == nil {
+		return value
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return structpb.NewNullValue()
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return structpb.NewNullValue()
+	}
+	value, err := structpb.NewValue(generic)
+	if err != nil {
+		return structpb.NewNullValue()
+	}
+	return value
+}
@@ -0,0 +1,171 @@
+// Package grpcapi serves proto/collegepb over gRPC for internal consumers.
+// Handlers call the same service functions as the REST controllers.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"strings"
+
+	"gobackend/models"
+	"gobackend/proto/collegepb"
+	"gobackend/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type collegeServer struct {
+	collegepb.UnimplementedCollegeServiceServer
+}
+
+// Start serves the college gRPC service on port until the listener fails
+func Start(port string) error {
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+
+	initializeSubscriptions()
+
+	server := grpc.NewServer()
+	collegepb.RegisterCollegeServiceServer(server, &collegeServer{})
+	reflection.Register(server)
+
+	log.Printf("🛰️ gRPC server listening on :%s", port)
+	return server.Serve(listener)
+}
+
+func (s *collegeServer) GetCollege(ctx context.Context, req *collegepb.GetCollegeRequest) (*collegepb.College, error) {
+	var (
+		college *models.CollegeStats
+		err     error
+	)
+	switch key := req.Key.(type) {
+	case *collegepb.GetCollegeRequest_Id:
+		college, err = services.GetCollegeByID(key.Id, false)
+	case *collegepb.GetCollegeRequest_Name:
+		name := strings.TrimSpace(key.Name)
+		if name == "" {
+			return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+		}
+		college, _, err = services.ResolveCollege(name)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "id or name is required")
+	}
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	return toProtoCollege(college), nil
+}
+
+func (s *collegeServer) SearchColleges(ctx context.Context, req *collegepb.SearchCollegesRequest) (*collegepb.SearchCollegesResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	page, limit := int(req.Page), int(req.Limit)
+	if page == 0 {
+		page = 1
+	}
+	if limit == 0 {
+		limit = 10
+	}
+	if page < 1 || page > 1000 || limit < 1 || limit > 50 {
+		return nil, status.Error(codes.InvalidArgument, "page must be between 1 and 1000 and limit between 1 and 50")
+	}
+
+	results, err := services.SearchColleges(query, page, limit)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	response := &collegepb.SearchCollegesResponse{
+		Page:    int32(results.Page),
+		Limit:   int32(results.Limit),
+		Total:   results.Total,
+		Results: make([]*collegepb.SearchResult, 0, len(results.Results)),
+	}
+	for _, r := range results.Results {
+		response.Results = append(response.Results, &collegepb.SearchResult{
+			Id:            r.ID,
+			CollegeName:   r.CollegeName,
+			Country:       r.Country,
+			Location:      r.Location,
+			GlobalRanking: r.GlobalRanking,
+			Score:         r.Score,
+		})
+	}
+	return response, nil
+}
+
+func (s *collegeServer) ListCollegesByCountry(ctx context.Context, req *collegepb.ListCollegesByCountryRequest) (*collegepb.ListCollegesResponse, error) {
+	country := strings.TrimSpace(req.Country)
+	if country == "" {
+		return nil, status.Error(codes.InvalidArgument, "country is required")
+	}
+	if req.PageSize < 0 || req.PageSize > services.MaxPageLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must be between 1 and %d", services.MaxPageLimit)
+	}
+	if _, ok := services.SortableCollegeFields[req.Sort]; req.Sort != "" && !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot sort by %q", req.Sort)
+	}
+
+	page, err := services.ListColleges(services.ListOptions{
+		Country: country,
+		Sort:    req.Sort,
+		Desc:    req.Descending,
+		Limit:   int(req.PageSize),
+		Cursor:  req.PageToken,
+		View:    services.ViewFull,
+	})
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+
+	response := &collegepb.ListCollegesResponse{
+		NextPageToken: page.NextCursor,
+		Colleges:      make([]*collegepb.College, 0, len(page.Colleges)),
+	}
+	for i := range page.Colleges {
+		response.Colleges = append(response.Colleges, toProtoCollege(&page.Colleges[i]))
+	}
+	return response, nil
+}
+
+func (s *collegeServer) SubscribeNewColleges(req *collegepb.SubscribeNewCollegesRequest, stream grpc.ServerStreamingServer[collegepb.College]) error {
+	sub := subscribe(req.Countries)
+	defer unsubscribe(sub)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case college := <-sub.colleges:
+			if err := stream.Send(college); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// statusFromError maps service errors onto gRPC status codes
+func statusFromError(err error) error {
+	var validationErrs models.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrs):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, services.ErrInvalidID), errors.Is(err, services.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, services.ErrCollegeNotFound), errors.Is(err, services.ErrCollegeDeleted):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	log.Printf("❌ gRPC request failed: %v", err)
+	return status.Error(codes.Internal, "internal error")
+}
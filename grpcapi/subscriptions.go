@@ -0,0 +1,85 @@
+package grpcapi
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"gobackend/proto/collegepb"
+	"gobackend/services"
+)
+
+// subscriberBuffer is how many colleges a slow stream may fall behind by
+// before further ones are dropped for it.
+const subscriberBuffer = 64
+
+type subscriber struct {
+	countries map[string]bool
+	colleges  chan *collegepb.College
+}
+
+var (
+	subscribers   = make(map[*subscriber]bool)
+	subscribersMu sync.RWMutex
+	subscribeOnce sync.Once
+)
+
+// initializeSubscriptions feeds new colleges to SubscribeNewColleges streams.
+// While the change stream runs it reports every write, including this
+// process's own, so local notifications are skipped to send each college once.
+func initializeSubscriptions() {
+	subscribeOnce.Do(func() {
+		services.OnCollegeChange(func(change services.CollegeChange) {
+			if change.Op != services.ChangeUpsert || !change.Created || change.College == nil {
+				return
+			}
+			if !change.Streamed && services.ChangeStreamActive() {
+				return
+			}
+			publishNewCollege(toProtoCollege(change.College))
+		})
+	})
+}
+
+func subscribe(countries []string) *subscriber {
+	sub := &subscriber{
+		countries: make(map[string]bool),
+		colleges:  make(chan *collegepb.College, subscriberBuffer),
+	}
+	for _, country := range countries {
+		if country = strings.TrimSpace(country); country != "" {
+			sub.countries[strings.ToLower(country)] = true
+		}
+	}
+
+	subscribersMu.Lock()
+	subscribers[sub] = true
+	subscribersMu.Unlock()
+	return sub
+}
+
+func unsubscribe(sub *subscriber) {
+	subscribersMu.Lock()
+	delete(subscribers, sub)
+	subscribersMu.Unlock()
+}
+
+// publishNewCollege hands college to every interested stream without
+// blocking on slow ones.
+func publishNewCollege(college *collegepb.College) {
+	country := strings.ToLower(college.Country)
+
+	subscribersMu.RLock()
+	defer subscribersMu.RUnlock()
+
+	for sub := range subscribers {
+		if len(sub.countries) > 0 && !sub.countries[country] {
+			continue
+		}
+		select {
+		case sub.colleges <- college:
+		default:
+			log.Printf("⚠️ gRPC subscriber is %d colleges behind, dropping %s", subscriberBuffer, college.CollegeName)
+		}
+	}
+}
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
 
 	"gobackend/config"
+	"gobackend/gql"
+	"gobackend/grpcapi"
 	"gobackend/routes"
 	"gobackend/services"
 
@@ -21,6 +24,11 @@ func main() {
 	// Initialize cache
 	services.InitializeCache()
 	log.Println("✅ Cache initialized (1 hour TTL)")
+	services.InitializeAnalytics()
+	services.InitializeRealtimeLimits()
+	if err := gql.InitializeSchema(); err != nil {
+		log.Fatal(" GraphQL schema failed:", err)
+	}
 
 	// Load environment variables
 	port := os.Getenv("PORT")
@@ -42,6 +50,33 @@ func main() {
 	}
 	defer config.DisconnectDatabase()
 
+	services.EnsureIndexes()
+	go services.BackfillRankingValues()
+	go services.BackfillFilterKeys()
+	services.InitializeProgramIndex()
+	services.InitializeSuggestions()
+	services.InitializeCountryCounts()
+	services.InitializeEventLog()
+	services.InitializeEventBus()
+
+	// Watch college_details so writes from any source reach WebSocket clients
+	if os.Getenv("DISABLE_CHANGE_STREAM") != "true" {
+		go services.WatchCollegeChanges(context.Background())
+	}
+
+	// Serve the gRPC API for internal consumers alongside HTTP
+	if os.Getenv("DISABLE_GRPC") != "true" {
+		grpcPort := os.Getenv("GRPC_PORT")
+		if grpcPort == "" {
+			grpcPort = "9090"
+		}
+		go func() {
+			if err := grpcapi.Start(grpcPort); err != nil {
+				log.Printf("⚠️ gRPC server stopped: %v", err)
+			}
+		}()
+	}
+
 	// Setup routes
 	router := routes.SetupRoutes()
 
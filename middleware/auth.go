@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+
+	"gobackend/models"
+	"gobackend/utils"
+)
+
+// AdminAuthMiddleware only lets requests through that present the key from
+// ADMIN_API_KEY, either as "Authorization: Bearer <key>" or "X-API-Key: <key>".
+// When ADMIN_API_KEY is not set every admin request is rejected.
+func AdminAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expected := os.Getenv("ADMIN_API_KEY")
+		if expected == "" {
+			utils.RespondError(w, http.StatusServiceUnavailable, models.ErrCodeUnavailable, "admin API is disabled")
+			return
+		}
+
+		provided := r.Header.Get("X-API-Key")
+		if auth := r.Header.Get("Authorization"); provided == "" && strings.HasPrefix(auth, "Bearer ") {
+			provided = strings.TrimPrefix(auth, "Bearer ")
+		}
+
+		if provided == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(expected)) != 1 {
+			utils.RespondError(w, http.StatusUnauthorized, models.ErrCodeUnauthorized, "invalid or missing API key")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+
+	"gobackend/utils"
+)
+
+// Cache-Control policies for read endpoints
+const (
+	// CacheRecord suits single college records, which change rarely
+	CacheRecord = "public, max-age=300"
+	// CacheList suits lists and searches, which change whenever a college is added
+	CacheList = "public, max-age=60"
+	// CacheAggregate suits countries and analytics, recomputed every few minutes
+	CacheAggregate = "public, max-age=600"
+	// CacheNone is for responses that must always be fetched fresh; NoStore
+	// sets it
+	CacheNone = "no-store"
+)
+
+// bufferedResponse holds a handler's response so ConditionalGet can replace
+// it with 304 Not Modified. Headers go straight to the real writer.
+type bufferedResponse struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferedResponse) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	return b.body.Write(p)
+}
+
+// ConditionalGet sets cacheControl on successful GET responses and answers
+// 304 Not Modified when the client's If-None-Match matches the response's
+// ETag or, failing an If-None-Match, when If-Modified-Since is not older
+// than its Last-Modified.
+func ConditionalGet(cacheControl string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next(w, r)
+			return
+		}
+
+		buffered := &bufferedResponse{ResponseWriter: w}
+		next(buffered, r)
+		if buffered.status == 0 {
+			buffered.status = http.StatusOK
+		}
+
+		if buffered.status == http.StatusOK {
+			w.Header().Set("Cache-Control", cacheControl)
+			if notModified(r, w.Header()) {
+				w.Header().Del("Content-Type")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+
+		w.WriteHeader(buffered.status)
+		if r.Method != http.MethodHead {
+			w.Write(buffered.body.Bytes())
+		}
+	}
+}
+
+// noStoreResponse drops validators as the handler's response starts, since a
+// response that must not be stored has nothing to revalidate
+type noStoreResponse struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (n *noStoreResponse) WriteHeader(status int) {
+	if !n.wroteHeader {
+		n.wroteHeader = true
+		n.Header().Del("ETag")
+		n.Header().Del("Last-Modified")
+		n.Header().Set("Cache-Control", CacheNone)
+	}
+	n.ResponseWriter.WriteHeader(status)
+}
+
+func (n *noStoreResponse) Write(p []byte) (int, error) {
+	if !n.wroteHeader {
+		n.WriteHeader(http.StatusOK)
+	}
+	return n.ResponseWriter.Write(p)
+}
+
+// NoStore marks responses as never cacheable. Unlike ConditionalGet it sends
+// no ETag or Last-Modified and never answers 304 Not Modified.
+func NoStore(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(&noStoreResponse{ResponseWriter: w}, r)
+	}
+}
+
+func notModified(r *http.Request, header http.Header) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		etag := header.Get("ETag")
+		if etag == "" {
+			return false
+		}
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || utils.CompareHashes(candidate, etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(header.Get("Last-Modified"))
+	if err != nil {
+		return false
+	}
+	return !modified.After(since.Truncate(time.Second))
+}
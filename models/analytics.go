@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// FeeSummary describes the yearly fees reported for one study level in a
+// country. Colleges without fee data for the level are left out.
+type FeeSummary struct {
+	CollegesReporting int     `json:"colleges_reporting"`
+	MedianMin         float64 `json:"median_min"`
+	MedianMax         float64 `json:"median_max"`
+	Lowest            int     `json:"lowest"`
+	Highest           int     `json:"highest"`
+}
+
+// RankingDistribution counts colleges per global ranking band
+type RankingDistribution struct {
+	Top100            int    `json:"top_100"`
+	Top200            int    `json:"101_200"`
+	Top500            int    `json:"201_500"`
+	Top1000           int    `json:"501_1000"`
+	Beyond            int    `json:"1000_plus"`
+	Unranked          int    `json:"unranked"`
+	BestRank          int    `json:"best_rank,omitempty"`
+	BestRankedCollege string `json:"best_ranked_college,omitempty"`
+}
+
+// CountryAnalytics is the aggregated view of every college in a country
+type CountryAnalytics struct {
+	Country                    string              `json:"country"`
+	Colleges                   int                 `json:"colleges"`
+	UGFees                     FeeSummary          `json:"ug_fees"`
+	PGFees                     FeeSummary          `json:"pg_fees"`
+	PhDFees                    FeeSummary          `json:"phd_fees"`
+	AverageMalePercentage      float64             `json:"average_male_percentage"`
+	AverageFemalePercentage    float64             `json:"average_female_percentage"`
+	TotalInternationalStudents int                 `json:"total_international_students"`
+	Rankings                   RankingDistribution `json:"rankings"`
+}
+
+// AnalyticsResponse wraps country analytics with the time they were computed
+type AnalyticsResponse struct {
+	GeneratedAt time.Time          `json:"generated_at"`
+	Countries   []CountryAnalytics `json:"countries"`
+}
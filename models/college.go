@@ -1,24 +1,45 @@
 package models
 
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
 type CollegeStats struct {
-	CollegeName           string          `json:"college_name" bson:"college_name"`
-	Country               string          `json:"country" bson:"country"`
-	About                 string          `json:"about" bson:"about"`
-	Location              string          `json:"location" bson:"location"`
-	Summary               string          `json:"summary" bson:"summary"`
-	UGPrograms            []string        `json:"ug_programs" bson:"ug_programs"`
-	PGPrograms            []string        `json:"pg_programs" bson:"pg_programs"`
-	PhDPrograms           []string        `json:"phd_programs" bson:"phd_programs"`
-	Fees                  FeesInfo        `json:"fees" bson:"fees"`
-	Scholarships          []string        `json:"scholarships" bson:"scholarships"`
-	StudentGenderRatio    GenderRatio     `json:"student_gender_ratio" bson:"student_gender_ratio"`
-	FacultyStaff          int             `json:"faculty_staff" bson:"faculty_staff"`
-	InternationalStudents int             `json:"international_students" bson:"international_students"`
-	GlobalRanking         string          `json:"global_ranking" bson:"global_ranking"`
-	Departments           []string        `json:"departments" bson:"departments"`
-	StudentStatistics     []StatisticItem `json:"student_statistics" bson:"student_statistics"`
-	AdditionalDetails     []StatisticItem `json:"additional_details" bson:"additional_details"`
-	Sources               []string        `json:"sources" bson:"sources"`
+	ID                    primitive.ObjectID `json:"id,omitzero" bson:"_id,omitempty"`
+	CollegeName           string             `json:"college_name" bson:"college_name"`
+	Aliases               []string           `json:"aliases,omitempty" bson:"aliases,omitempty"`
+	Country               string             `json:"country" bson:"country"`
+	About                 string             `json:"about" bson:"about"`
+	Location              string             `json:"location" bson:"location"`
+	Summary               string             `json:"summary" bson:"summary"`
+	UGPrograms            []string           `json:"ug_programs" bson:"ug_programs"`
+	PGPrograms            []string           `json:"pg_programs" bson:"pg_programs"`
+	PhDPrograms           []string           `json:"phd_programs" bson:"phd_programs"`
+	Fees                  FeesInfo           `json:"fees" bson:"fees"`
+	Scholarships          []string           `json:"scholarships" bson:"scholarships"`
+	StudentGenderRatio    GenderRatio        `json:"student_gender_ratio" bson:"student_gender_ratio"`
+	FacultyStaff          int                `json:"faculty_staff" bson:"faculty_staff"`
+	InternationalStudents int                `json:"international_students" bson:"international_students"`
+	GlobalRanking         string             `json:"global_ranking" bson:"global_ranking"`
+	GlobalRankingValue    int                `json:"global_ranking_value,omitempty" bson:"global_ranking_value,omitempty"`
+	Departments           []string           `json:"departments" bson:"departments"`
+	StudentStatistics     []StatisticItem    `json:"student_statistics" bson:"student_statistics"`
+	AdditionalDetails     []StatisticItem    `json:"additional_details" bson:"additional_details"`
+	Sources               []string           `json:"sources" bson:"sources"`
+
+	// ProgramKeys and DepartmentKeys back the program and department filters;
+	// see SetDerivedFields
+	ProgramKeys    []string `json:"-" bson:"program_keys"`
+	DepartmentKeys []string `json:"-" bson:"department_keys"`
+
+	// ManuallyEdited is set when an admin creates or corrects a record;
+	// background Gemini refreshes leave such records alone.
+	ManuallyEdited bool       `json:"manually_edited,omitempty" bson:"manually_edited,omitempty"`
+	CreatedAt      time.Time  `json:"created_at,omitzero" bson:"created_at,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at,omitzero" bson:"updated_at,omitempty"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
 }
 
 type FeesInfo struct {
@@ -1,11 +1,50 @@
 package models
 
-// APIResponse is a generic API response wrapper
+import "encoding/json"
+
+// Error codes carried in APIError.Code
+const (
+	ErrCodeBadRequest   = "bad_request"
+	ErrCodeValidation   = "validation_failed"
+	ErrCodeUnauthorized = "unauthorized"
+	ErrCodeForbidden    = "forbidden"
+	ErrCodeNotFound     = "not_found"
+	ErrCodeConflict     = "conflict"
+	ErrCodeGone         = "gone"
+	ErrCodeTooLarge     = "payload_too_large"
+	ErrCodeRateLimited  = "rate_limited"
+	ErrCodeUpstream     = "upstream_error"
+	ErrCodeUnavailable  = "service_unavailable"
+	ErrCodeInternal     = "internal_error"
+)
+
+// APIResponse is the envelope every REST endpoint responds with. Exactly one
+// of Data and Error is set.
 type APIResponse struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success bool          `json:"success"`
+	Data    interface{}   `json:"data,omitempty"`
+	Error   *APIError     `json:"error,omitempty"`
+	Message string        `json:"message,omitempty"`
+	Meta    *ResponseMeta `json:"meta,omitempty"`
+}
+
+// APIError describes why a request failed. Fields lists per-field problems
+// for validation errors; Details carries endpoint-specific context.
+type APIError struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+	Details interface{}  `json:"details,omitempty"`
+}
+
+// ResponseMeta describes a list in Data. Lists paginated by cursor set
+// NextCursor (empty on the last page); page-numbered lists set Page and Total.
+type ResponseMeta struct {
+	Count      int    `json:"count"`
+	Limit      int    `json:"limit,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	Total      int64  `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // CollegeStatisticsResponse wraps college data for API responses
@@ -28,13 +67,121 @@ type SearchRequest struct {
 	Country     string `json:"country" form:"country"`
 }
 
-// WebSocketMessage represents a WebSocket message
+// CollegeSummary is the compact college shape returned by list endpoints
+type CollegeSummary struct {
+	ID                    string      `json:"id"`
+	CollegeName           string      `json:"college_name"`
+	Country               string      `json:"country"`
+	Location              string      `json:"location"`
+	GlobalRanking         string      `json:"global_ranking"`
+	Fees                  FeesInfo    `json:"fees"`
+	StudentGenderRatio    GenderRatio `json:"student_gender_ratio"`
+	FacultyStaff          int         `json:"faculty_staff"`
+	InternationalStudents int         `json:"international_students"`
+}
+
+// ToSummary returns the list view of a college
+func (c CollegeStats) ToSummary() CollegeSummary {
+	return CollegeSummary{
+		ID:                    c.ID.Hex(),
+		CollegeName:           c.CollegeName,
+		Country:               c.Country,
+		Location:              c.Location,
+		GlobalRanking:         c.GlobalRanking,
+		Fees:                  c.Fees,
+		StudentGenderRatio:    c.StudentGenderRatio,
+		FacultyStaff:          c.FacultyStaff,
+		InternationalStudents: c.InternationalStudents,
+	}
+}
+
+// CollegeListItem is a CollegeSummary plus the student statistics that list
+// endpoints have always returned as data
+type CollegeListItem struct {
+	CollegeSummary
+	Data []StatisticItem `json:"data"`
+}
+
+// ToListItem returns the list endpoint view of a college
+func (c CollegeStats) ToListItem() CollegeListItem {
+	return CollegeListItem{CollegeSummary: c.ToSummary(), Data: c.StudentStatistics}
+}
+
+// ComparisonRow lines up one attribute across the compared colleges. Values
+// follow the order of Comparison.Colleges and are null where a college has no
+// data. Best holds the indexes of the winning colleges (several on a tie).
+type ComparisonRow struct {
+	Key        string        `json:"key"`
+	Label      string        `json:"label"`
+	Group      string        `json:"group"`
+	Preference string        `json:"preference"`
+	Values     []interface{} `json:"values"`
+	Best       []int         `json:"best"`
+}
+
+// Comparison is the side-by-side matrix returned by /api/compare
+type Comparison struct {
+	Colleges []CollegeSummary `json:"colleges"`
+	Rows     []ComparisonRow  `json:"rows"`
+}
+
+// SearchResult is a single ranked hit from the full-text college search
+type SearchResult struct {
+	ID            string              `json:"id"`
+	CollegeName   string              `json:"college_name"`
+	Country       string              `json:"country"`
+	Location      string              `json:"location"`
+	GlobalRanking string              `json:"global_ranking"`
+	Score         float64             `json:"score"`
+	Highlights    map[string][]string `json:"highlights"`
+}
+
+// SearchResponse is a page of full-text search results
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+	Page    int            `json:"page"`
+	Limit   int            `json:"limit"`
+	Total   int64          `json:"total"`
+}
+
+// WebSocket commands sent by clients, and the replies to them
+const (
+	WsSubscribe         = "subscribe"
+	WsUnsubscribe       = "unsubscribe"
+	WsListSubscriptions = "list_subscriptions"
+	WsFetchCollege      = "fetch_college"
+
+	WsSubscribed    = "subscribed"
+	WsUnsubscribed  = "unsubscribed"
+	WsSubscriptions = "subscriptions"
+	WsFetchProgress = "fetch_progress"
+	WsFetchResult   = "fetch_result"
+	WsError         = "error"
+	// WsResync tells a reconnecting client that the events it missed are no
+	// longer available and it should reload its data
+	WsResync = "resync"
+
+	// Paged snapshots of a country's colleges or of the country list
+	WsSnapshotBegin = "snapshot_begin"
+	WsSnapshotPage  = "snapshot_page"
+	WsSnapshotEnd   = "snapshot_end"
+)
+
+// WebSocketMessage represents a WebSocket message. Commands carry Topic or
+// Topics; replies echo the command's ID so clients can match them up.
 type WebSocketMessage struct {
-	Type    string      `json:"type"`
-	Data    interface{} `json:"data"`
-	Message string      `json:"message"`
-	Country string      `json:"country,omitempty"`
-	Count   int         `json:"count,omitempty"`
+	Type   string   `json:"type"`
+	ID     string   `json:"id,omitempty"`
+	Topic  string   `json:"topic,omitempty"`
+	Topics []string `json:"topics,omitempty"`
+	// CollegeName is the college a fetch_college command asks for
+	CollegeName string      `json:"college_name,omitempty"`
+	Data        interface{} `json:"data,omitempty"`
+	Code        string      `json:"code,omitempty"`
+	Message     string      `json:"message,omitempty"`
+	Country     string      `json:"country,omitempty"`
+	Count       int         `json:"count,omitempty"`
 }
 
 // CountryData represents country information
@@ -43,3 +190,60 @@ type CountryData struct {
 	Name string `json:"name"`
 	Code string `json:"code"`
 }
+
+// CountryCount is a country with the number of active colleges in it
+type CountryCount struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Colleges int    `json:"colleges"`
+}
+
+// RealtimeStats reports WebSocket and SSE connections and how often the
+// connection, queue and message limits have kicked in
+type RealtimeStats struct {
+	Connections int64  `json:"connections"`
+	Rejected    int64  `json:"rejected"`
+	Evicted     int64  `json:"evicted"`
+	Dropped     int64  `json:"dropped"`
+	Coalesced   int64  `json:"coalesced"`
+	Oversized   int64  `json:"oversized"`
+	QueuePolicy string `json:"queue_policy"`
+	QueueSize   int    `json:"queue_size"`
+}
+
+// Suggestion is an autocomplete match; Matched is the name or alias that
+// matched what was typed.
+type Suggestion struct {
+	ID          string  `json:"id"`
+	CollegeName string  `json:"college_name"`
+	Country     string  `json:"country"`
+	Matched     string  `json:"matched"`
+	Score       float64 `json:"score"`
+}
+
+// JSON-patch operation kinds used in college_updated events
+const (
+	PatchAdd     = "add"
+	PatchRemove  = "remove"
+	PatchReplace = "replace"
+)
+
+// PatchOperation is one RFC 6902 JSON-patch operation. Path is a JSON
+// pointer such as /fees/ug_yearly_min.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalJSON leaves out value for remove operations, which have none
+func (p PatchOperation) MarshalJSON() ([]byte, error) {
+	if p.Op == PatchRemove {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{p.Op, p.Path})
+	}
+	type operation PatchOperation
+	return json.Marshal(operation(p))
+}
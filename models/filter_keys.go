@@ -0,0 +1,45 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// filterKeySeparator splits words; + and # are kept for names like C++ and C#
+var filterKeySeparator = regexp.MustCompile(`[^\p{L}\p{N}+#]+`)
+
+// FilterKey lowercases text and reduces it to words separated by single
+// spaces, the form ProgramKeys and DepartmentKeys are stored in
+func FilterKey(text string) string {
+	return strings.TrimSpace(filterKeySeparator.ReplaceAllString(strings.ToLower(text), " "))
+}
+
+// FilterKeys returns every word suffix of the normalized values, so an
+// anchored prefix regex on the keys finds text starting at any word:
+// "M.Tech Data Science" gives "m tech data science", "tech data science",
+// "data science" and "science".
+func FilterKeys(values ...[]string) []string {
+	seen := make(map[string]bool)
+	keys := []string{}
+	for _, list := range values {
+		for _, value := range list {
+			words := strings.Fields(FilterKey(value))
+			for i := range words {
+				key := strings.Join(words[i:], " ")
+				if !seen[key] {
+					seen[key] = true
+					keys = append(keys, key)
+				}
+			}
+		}
+	}
+	return keys
+}
+
+// SetDerivedFields recomputes the fields stored only so they can be queried:
+// the numeric ranking and the program and department filter keys
+func (c *CollegeStats) SetDerivedFields() {
+	c.GlobalRankingValue = ParseRankingValue(c.GlobalRanking)
+	c.ProgramKeys = FilterKeys(c.UGPrograms, c.PGPrograms, c.PhDPrograms)
+	c.DepartmentKeys = FilterKeys(c.Departments)
+}
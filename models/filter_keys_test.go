@@ -0,0 +1,22 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterKeys(t *testing.T) {
+	got := FilterKeys([]string{"M.Tech Data Science", "B.Sc. (Hons) Data Science"}, []string{"C++ Programming", "  "})
+	want := []string{
+		"m tech data science", "tech data science", "data science", "science",
+		"b sc hons data science", "sc hons data science", "hons data science",
+		"c++ programming", "programming",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterKeys = %q, want %q", got, want)
+	}
+
+	if got := FilterKeys(); got == nil || len(got) != 0 {
+		t.Errorf("FilterKeys() = %#v, want an empty list", got)
+	}
+}
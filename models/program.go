@@ -0,0 +1,28 @@
+package models
+
+// Study levels used by the program index
+const (
+	LevelUG  = "ug"
+	LevelPG  = "pg"
+	LevelPhD = "phd"
+)
+
+// Program is a normalized program name, e.g. "M.Tech Computer Science"
+type Program struct {
+	Name       string `json:"name"`
+	Degree     string `json:"degree"`
+	Discipline string `json:"discipline"`
+	Level      string `json:"level"`
+}
+
+// ProgramCount is a program with the number of colleges offering it
+type ProgramCount struct {
+	Program
+	Colleges int `json:"colleges"`
+}
+
+// ProgramMatch is a college offering programs that matched a program search
+type ProgramMatch struct {
+	College         CollegeSummary `json:"college"`
+	MatchedPrograms []Program      `json:"matched_programs"`
+}
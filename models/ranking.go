@@ -0,0 +1,25 @@
+package models
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var rankingNumber = regexp.MustCompile(`\d[\d,]*`)
+
+// ParseRankingValue extracts a sortable number from a free-form ranking such
+// as "#45", "Top 100" or "501-600" (the best position in a band is used).
+// It returns 0 when the ranking has no number, e.g. "Not ranked".
+func ParseRankingValue(ranking string) int {
+	match := rankingNumber.FindString(ranking)
+	if match == "" {
+		return 0
+	}
+
+	value, err := strconv.Atoi(strings.ReplaceAll(match, ",", ""))
+	if err != nil {
+		return 0
+	}
+	return value
+}
@@ -0,0 +1,24 @@
+package models
+
+import "testing"
+
+func TestParseRankingValue(t *testing.T) {
+	tests := []struct {
+		ranking string
+		want    int
+	}{
+		{"#45", 45},
+		{"Top 100", 100},
+		{"501-600", 501},
+		{"QS World Rank 1,201-1,400", 1201},
+		{"Not ranked", 0},
+		{"", 0},
+		{"99999999999999999999", 0},
+	}
+
+	for _, tt := range tests {
+		if got := ParseRankingValue(tt.ranking); got != tt.want {
+			t.Errorf("ParseRankingValue(%q) = %d, want %d", tt.ranking, got, tt.want)
+		}
+	}
+}
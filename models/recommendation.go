@@ -0,0 +1,103 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RecommendationWeights are the relative importance of each score component.
+// They need not add up to one.
+type RecommendationWeights struct {
+	Ranking   float64 `json:"ranking"`
+	Placement float64 `json:"placement"`
+	Fees      float64 `json:"fees"`
+}
+
+// RecommendationRequest describes what a student is looking for
+type RecommendationRequest struct {
+	Budget    int                    `json:"budget"`
+	Countries []string               `json:"countries"`
+	Program   string                 `json:"program"`
+	Level     string                 `json:"level"`
+	Weights   *RecommendationWeights `json:"weights"`
+	Limit     int                    `json:"limit"`
+}
+
+// ScoreComponent explains one part of a recommendation score. Score is
+// between 0 and 1; Contribution is its share of the final score after
+// weighting. Components without data are listed with Available false and
+// their weight is spread over the others.
+type ScoreComponent struct {
+	Name         string      `json:"name"`
+	Available    bool        `json:"available"`
+	Value        interface{} `json:"value,omitempty"`
+	Score        float64     `json:"score"`
+	Weight       float64     `json:"weight"`
+	Contribution float64     `json:"contribution"`
+	Explanation  string      `json:"explanation"`
+}
+
+// Recommendation is a scored college; Score is between 0 and 100
+type Recommendation struct {
+	College         CollegeSummary   `json:"college"`
+	Score           float64          `json:"score"`
+	Components      []ScoreComponent `json:"components"`
+	MatchedPrograms []Program        `json:"matched_programs,omitempty"`
+}
+
+// RecommendationResponse is the ranked result of a recommendation request
+type RecommendationResponse struct {
+	Criteria        RecommendationRequest `json:"criteria"`
+	Considered      int                   `json:"considered"`
+	ExcludedBudget  int                   `json:"excluded_over_budget"`
+	ExcludedProgram int                   `json:"excluded_no_program"`
+	Results         []Recommendation      `json:"results"`
+}
+
+// Validate checks a recommendation request and fills in defaults
+func (r *RecommendationRequest) Validate() ValidationErrors {
+	var errs ValidationErrors
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if r.Budget < 0 {
+		add("budget", "must not be negative")
+	}
+	if len(r.Countries) > 20 {
+		add("countries", "must list at most 20 countries")
+	}
+	for i, country := range r.Countries {
+		if strings.TrimSpace(country) == "" || len(country) > 100 {
+			add(fmt.Sprintf("countries[%d]", i), "must be between 1 and 100 characters")
+		}
+	}
+	if len(r.Program) > 200 {
+		add("program", "must be at most 200 characters")
+	}
+
+	r.Level = strings.ToLower(r.Level)
+	switch r.Level {
+	case "", LevelUG, LevelPG, LevelPhD:
+	default:
+		add("level", "must be ug, pg or phd")
+	}
+
+	if r.Weights == nil {
+		r.Weights = &RecommendationWeights{Ranking: 0.4, Placement: 0.3, Fees: 0.3}
+	}
+	w := r.Weights
+	if w.Ranking < 0 || w.Placement < 0 || w.Fees < 0 {
+		add("weights", "must not be negative")
+	} else if w.Ranking+w.Placement+w.Fees == 0 {
+		add("weights", "at least one weight must be positive")
+	}
+
+	if r.Limit == 0 {
+		r.Limit = 10
+	} else if r.Limit < 1 || r.Limit > 50 {
+		add("limit", "must be between 1 and 50")
+	}
+
+	return errs
+}
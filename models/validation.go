@@ -0,0 +1,94 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError describes a single invalid field in a request payload
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors is returned when a payload fails validation
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, 0, len(v))
+	for _, fe := range v {
+		messages = append(messages, fe.Field+": "+fe.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// Validate checks the fields an admin is allowed to set on a college record
+func (c *CollegeStats) Validate() ValidationErrors {
+	var errs ValidationErrors
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if strings.TrimSpace(c.CollegeName) == "" {
+		add("college_name", "is required")
+	} else if len(c.CollegeName) > 200 {
+		add("college_name", "must be at most 200 characters")
+	}
+
+	for i, alias := range c.Aliases {
+		if strings.TrimSpace(alias) == "" || len(alias) > 200 {
+			add(fmt.Sprintf("aliases[%d]", i), "must be between 1 and 200 characters")
+		}
+	}
+
+	if strings.TrimSpace(c.Country) == "" {
+		add("country", "is required")
+	}
+
+	feeRanges := []struct {
+		level    string
+		min, max int
+	}{
+		{"ug", c.Fees.UGYearlyMin, c.Fees.UGYearlyMax},
+		{"pg", c.Fees.PGYearlyMin, c.Fees.PGYearlyMax},
+		{"phd", c.Fees.PhDYearlyMin, c.Fees.PhDYearlyMax},
+	}
+	for _, fr := range feeRanges {
+		if fr.min < 0 || fr.max < 0 {
+			add("fees."+fr.level+"_yearly", "must not be negative")
+		} else if fr.max != 0 && fr.min > fr.max {
+			add("fees."+fr.level+"_yearly", "minimum %d is greater than maximum %d", fr.min, fr.max)
+		}
+	}
+
+	male, female := c.StudentGenderRatio.MalePercentage, c.StudentGenderRatio.FemalePercentage
+	if male < 0 || male > 100 {
+		add("student_gender_ratio.male_percentage", "must be between 0 and 100")
+	}
+	if female < 0 || female > 100 {
+		add("student_gender_ratio.female_percentage", "must be between 0 and 100")
+	}
+	if male+female > 100 {
+		add("student_gender_ratio", "percentages add up to %d", male+female)
+	}
+
+	if c.FacultyStaff < 0 {
+		add("faculty_staff", "must not be negative")
+	}
+	if c.InternationalStudents < 0 {
+		add("international_students", "must not be negative")
+	}
+
+	for i, stat := range c.StudentStatistics {
+		if strings.TrimSpace(stat.Category) == "" {
+			add(fmt.Sprintf("student_statistics[%d].category", i), "is required")
+		}
+	}
+	for i, stat := range c.AdditionalDetails {
+		if strings.TrimSpace(stat.Category) == "" {
+			add(fmt.Sprintf("additional_details[%d].category", i), "is required")
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,1082 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: proto/collegepb/college.proto
+
+package collegepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type College struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CollegeName           string                 `protobuf:"bytes,2,opt,name=college_name,json=collegeName,proto3" json:"college_name,omitempty"`
+	Aliases               []string               `protobuf:"bytes,3,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	Country               string                 `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
+	About                 string                 `protobuf:"bytes,5,opt,name=about,proto3" json:"about,omitempty"`
+	Location              string                 `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`
+	Summary               string                 `protobuf:"bytes,7,opt,name=summary,proto3" json:"summary,omitempty"`
+	UgPrograms            []string               `protobuf:"bytes,8,rep,name=ug_programs,json=ugPrograms,proto3" json:"ug_programs,omitempty"`
+	PgPrograms            []string               `protobuf:"bytes,9,rep,name=pg_programs,json=pgPrograms,proto3" json:"pg_programs,omitempty"`
+	PhdPrograms           []string               `protobuf:"bytes,10,rep,name=phd_programs,json=phdPrograms,proto3" json:"phd_programs,omitempty"`
+	Fees                  *Fees                  `protobuf:"bytes,11,opt,name=fees,proto3" json:"fees,omitempty"`
+	Scholarships          []string               `protobuf:"bytes,12,rep,name=scholarships,proto3" json:"scholarships,omitempty"`
+	StudentGenderRatio    *GenderRatio           `protobuf:"bytes,13,opt,name=student_gender_ratio,json=studentGenderRatio,proto3" json:"student_gender_ratio,omitempty"`
+	FacultyStaff          int32                  `protobuf:"varint,14,opt,name=faculty_staff,json=facultyStaff,proto3" json:"faculty_staff,omitempty"`
+	InternationalStudents int32                  `protobuf:"varint,15,opt,name=international_students,json=internationalStudents,proto3" json:"international_students,omitempty"`
+	GlobalRanking         string                 `protobuf:"bytes,16,opt,name=global_ranking,json=globalRanking,proto3" json:"global_ranking,omitempty"`
+	GlobalRankingValue    int32                  `protobuf:"varint,17,opt,name=global_ranking_value,json=globalRankingValue,proto3" json:"global_ranking_value,omitempty"`
+	Departments           []string               `protobuf:"bytes,18,rep,name=departments,proto3" json:"departments,omitempty"`
+	StudentStatistics     []*Statistic           `protobuf:"bytes,19,rep,name=student_statistics,json=studentStatistics,proto3" json:"student_statistics,omitempty"`
+	AdditionalDetails     []*Statistic           `protobuf:"bytes,20,rep,name=additional_details,json=additionalDetails,proto3" json:"additional_details,omitempty"`
+	Sources               []string               `protobuf:"bytes,21,rep,name=sources,proto3" json:"sources,omitempty"`
+	ManuallyEdited        bool                   `protobuf:"varint,22,opt,name=manually_edited,json=manuallyEdited,proto3" json:"manually_edited,omitempty"`
+	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt             *timestamppb.Timestamp `protobuf:"bytes,24,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *College) Reset() {
+	*x = College{}
+	mi := &file_proto_collegepb_college_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *College) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*College) ProtoMessage() {}
+
+func (x *College) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collegepb_college_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use College.ProtoReflect.Descriptor instead.
+func (*College) Descriptor() ([]byte, []int) {
+	return file_proto_collegepb_college_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *College) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *College) GetCollegeName() string {
+	if x != nil {
+		return x.CollegeName
+	}
+	return ""
+}
+
+func (x *College) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *College) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *College) GetAbout() string {
+	if x != nil {
+		return x.About
+	}
+	return ""
+}
+
+func (x *College) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *College) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *College) GetUgPrograms() []string {
+	if x != nil {
+		return x.UgPrograms
+	}
+	return nil
+}
+
+func (x *College) GetPgPrograms() []string {
+	if x != nil {
+		return x.PgPrograms
+	}
+	return nil
+}
+
+func (x *College) GetPhdPrograms() []string {
+	if x != nil {
+		return x.PhdPrograms
+	}
+	return nil
+}
+
+func (x *College) GetFees() *Fees {
+	if x != nil {
+		return x.Fees
+	}
+	return nil
+}
+
+func (x *College) GetScholarships() []string {
+	if x != nil {
+		return x.Scholarships
+	}
+	return nil
+}
+
+func (x *College) GetStudentGenderRatio() *GenderRatio {
+	if x != nil {
+		return x.StudentGenderRatio
+	}
+	return nil
+}
+
+func (x *College) GetFacultyStaff() int32 {
+	if x != nil {
+		return x.FacultyStaff
+	}
+	return 0
+}
+
+func (x *College) GetInternationalStudents() int32 {
+	if x != nil {
+		return x.InternationalStudents
+	}
+	return 0
+}
+
+func (x *College) GetGlobalRanking() string {
+	if x != nil {
+		return x.GlobalRanking
+	}
+	return ""
+}
+
+func (x *College) GetGlobalRankingValue() int32 {
+	if x != nil {
+		return x.GlobalRankingValue
+	}
+	return 0
+}
+
+func (x *College) GetDepartments() []string {
+	if x != nil {
+		return x.Departments
+	}
+	return nil
+}
+
+func (x *College) GetStudentStatistics() []*Statistic {
+	if x != nil {
+		return x.StudentStatistics
+	}
+	return nil
+}
+
+func (x *College) GetAdditionalDetails() []*Statistic {
+	if x != nil {
+		return x.AdditionalDetails
+	}
+	return nil
+}
+
+func (x *College) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *College) GetManuallyEdited() bool {
+	if x != nil {
+		return x.ManuallyEdited
+	}
+	return false
+}
+
+func (x *College) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *College) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Fees struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UgYearlyMin   int32                  `protobuf:"varint,1,opt,name=ug_yearly_min,json=ugYearlyMin,proto3" json:"ug_yearly_min,omitempty"`
+	UgYearlyMax   int32                  `protobuf:"varint,2,opt,name=ug_yearly_max,json=ugYearlyMax,proto3" json:"ug_yearly_max,omitempty"`
+	PgYearlyMin   int32                  `protobuf:"varint,3,opt,name=pg_yearly_min,json=pgYearlyMin,proto3" json:"pg_yearly_min,omitempty"`
+	PgYearlyMax   int32                  `protobuf:"varint,4,opt,name=pg_yearly_max,json=pgYearlyMax,proto3" json:"pg_yearly_max,omitempty"`
+	PhdYearlyMin  int32                  `protobuf:"varint,5,opt,name=phd_yearly_min,json=phdYearlyMin,proto3" json:"phd_yearly_min,omitempty"`
+	PhdYearlyMax  int32                  `protobuf:"varint,6,opt,name=phd_yearly_max,json=phdYearlyMax,proto3" json:"phd_yearly_max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fees) Reset() {
+	*x = Fees{}
+	mi := &file_proto_collegepb_college_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fees) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fees) ProtoMessage() {}
+
+func (x *Fees) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collegepb_college_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fees.ProtoReflect.Descriptor instead.
+func (*Fees) Descriptor() ([]byte, []int) {
+	return file_proto_collegepb_college_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Fees) GetUgYearlyMin() int32 {
+	if x != nil {
+		return x.UgYearlyMin
+	}
+	return 0
+}
+
+func (x *Fees) GetUgYearlyMax() int32 {
+	if x != nil {
+		return x.UgYearlyMax
+	}
+	return 0
+}
+
+func (x *Fees) GetPgYearlyMin() int32 {
+	if x != nil {
+		return x.PgYearlyMin
+	}
+	return 0
+}
+
+func (x *Fees) GetPgYearlyMax() int32 {
+	if x != nil {
+		return x.PgYearlyMax
+	}
+	return 0
+}
+
+func (x *Fees) GetPhdYearlyMin() int32 {
+	if x != nil {
+		return x.PhdYearlyMin
+	}
+	return 0
+}
+
+func (x *Fees) GetPhdYearlyMax() int32 {
+	if x != nil {
+		return x.PhdYearlyMax
+	}
+	return 0
+}
+
+type GenderRatio struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MalePercentage   int32                  `protobuf:"varint,1,opt,name=male_percentage,json=malePercentage,proto3" json:"male_percentage,omitempty"`
+	FemalePercentage int32                  `protobuf:"varint,2,opt,name=female_percentage,json=femalePercentage,proto3" json:"female_percentage,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GenderRatio) Reset() {
+	*x = GenderRatio{}
+	mi := &file_proto_collegepb_college_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenderRatio) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenderRatio) ProtoMessage() {}
+
+func (x *GenderRatio) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collegepb_college_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenderRatio.ProtoReflect.Descriptor instead.
+func (*GenderRatio) Descriptor() ([]byte, []int) {
+	return file_proto_collegepb_college_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GenderRatio) GetMalePercentage() int32 {
+	if x != nil {
+		return x.MalePercentage
+	}
+	return 0
+}
+
+func (x *GenderRatio) GetFemalePercentage() int32 {
+	if x != nil {
+		return x.FemalePercentage
+	}
+	return 0
+}
+
+type Statistic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Value         *structpb.Value        `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Statistic) Reset() {
+	*x = Statistic{}
+	mi := &file_proto_collegepb_college_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Statistic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Statistic) ProtoMessage() {}
+
+func (x *Statistic) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collegepb_college_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Statistic.ProtoReflect.Descriptor instead.
+func (*Statistic) Descriptor() ([]byte, []int) {
+	return file_proto_collegepb_college_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Statistic) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Statistic) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type GetCollegeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Key:
+	//
+	//	*GetCollegeRequest_Id
+	//	*GetCollegeRequest_Name
+	Key           isGetCollegeRequest_Key `protobuf_oneof:"key"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCollegeRequest) Reset() {
+	*x = GetCollegeRequest{}
+	mi := &file_proto_collegepb_college_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCollegeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollegeRequest) ProtoMessage() {}
+
+func (x *GetCollegeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collegepb_college_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollegeRequest.ProtoReflect.Descriptor instead.
+func (*GetCollegeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_collegepb_college_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCollegeRequest) GetKey() isGetCollegeRequest_Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *GetCollegeRequest) GetId() string {
+	if x != nil {
+		if x, ok := x.Key.(*GetCollegeRequest_Id); ok {
+			return x.Id
+		}
+	}
+	return ""
+}
+
+func (x *GetCollegeRequest) GetName() string {
+	if x != nil {
+		if x, ok := x.Key.(*GetCollegeRequest_Name); ok {
+			return x.Name
+		}
+	}
+	return ""
+}
+
+type isGetCollegeRequest_Key interface {
+	isGetCollegeRequest_Key()
+}
+
+type GetCollegeRequest_Id struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3,oneof"`
+}
+
+type GetCollegeRequest_Name struct {
+	Name string `protobuf:"bytes,2,opt,name=name,proto3,oneof"`
+}
+
+func (*GetCollegeRequest_Id) isGetCollegeRequest_Key() {}
+
+func (*GetCollegeRequest_Name) isGetCollegeRequest_Key() {}
+
+type SearchCollegesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// 1-based; defaults to 1
+	Page int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// 1 to 50; defaults to 10
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCollegesRequest) Reset() {
+	*x = SearchCollegesRequest{}
+	mi := &file_proto_collegepb_college_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCollegesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCollegesRequest) ProtoMessage() {}
+
+func (x *SearchCollegesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collegepb_college_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCollegesRequest.ProtoReflect.Descriptor instead.
+func (*SearchCollegesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_collegepb_college_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SearchCollegesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchCollegesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchCollegesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CollegeName   string                 `protobuf:"bytes,2,opt,name=college_name,json=collegeName,proto3" json:"college_name,omitempty"`
+	Country       string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Location      string                 `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	GlobalRanking string                 `protobuf:"bytes,5,opt,name=global_ranking,json=globalRanking,proto3" json:"global_ranking,omitempty"`
+	Score         float64                `protobuf:"fixed64,6,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_proto_collegepb_college_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collegepb_college_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_proto_collegepb_college_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SearchResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SearchResult) GetCollegeName() string {
+	if x != nil {
+		return x.CollegeName
+	}
+	return ""
+}
+
+func (x *SearchResult) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *SearchResult) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *SearchResult) GetGlobalRanking() string {
+	if x != nil {
+		return x.GlobalRanking
+	}
+	return ""
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type SearchCollegesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchCollegesResponse) Reset() {
+	*x = SearchCollegesResponse{}
+	mi := &file_proto_collegepb_college_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchCollegesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchCollegesResponse) ProtoMessage() {}
+
+func (x *SearchCollegesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collegepb_college_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchCollegesResponse.ProtoReflect.Descriptor instead.
+func (*SearchCollegesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_collegepb_college_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchCollegesResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchCollegesResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchCollegesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchCollegesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ListCollegesByCountryRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Country string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	// 1 to 100; defaults to 20
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from the previous response
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Any sort accepted by GET /api/colleges; defaults to college_name
+	Sort          string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	Descending    bool   `protobuf:"varint,5,opt,name=descending,proto3" json:"descending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollegesByCountryRequest) Reset() {
+	*x = ListCollegesByCountryRequest{}
+	mi := &file_proto_collegepb_college_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollegesByCountryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollegesByCountryRequest) ProtoMessage() {}
+
+func (x *ListCollegesByCountryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collegepb_college_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollegesByCountryRequest.ProtoReflect.Descriptor instead.
+func (*ListCollegesByCountryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_collegepb_college_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListCollegesByCountryRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ListCollegesByCountryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListCollegesByCountryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListCollegesByCountryRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListCollegesByCountryRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+type ListCollegesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Colleges      []*College             `protobuf:"bytes,1,rep,name=colleges,proto3" json:"colleges,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollegesResponse) Reset() {
+	*x = ListCollegesResponse{}
+	mi := &file_proto_collegepb_college_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollegesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollegesResponse) ProtoMessage() {}
+
+func (x *ListCollegesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collegepb_college_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollegesResponse.ProtoReflect.Descriptor instead.
+func (*ListCollegesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_collegepb_college_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListCollegesResponse) GetColleges() []*College {
+	if x != nil {
+		return x.Colleges
+	}
+	return nil
+}
+
+func (x *ListCollegesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SubscribeNewCollegesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Countries     []string               `protobuf:"bytes,1,rep,name=countries,proto3" json:"countries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeNewCollegesRequest) Reset() {
+	*x = SubscribeNewCollegesRequest{}
+	mi := &file_proto_collegepb_college_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeNewCollegesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeNewCollegesRequest) ProtoMessage() {}
+
+func (x *SubscribeNewCollegesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_collegepb_college_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeNewCollegesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeNewCollegesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_collegepb_college_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SubscribeNewCollegesRequest) GetCountries() []string {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+var File_proto_collegepb_college_proto protoreflect.FileDescriptor
+
+const file_proto_collegepb_college_proto_rawDesc = "" +
+	"\n" +
+	"\x1dproto/collegepb/college.proto\x12\n" +
+	"college.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd2\a\n" +
+	"\aCollege\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fcollege_name\x18\x02 \x01(\tR\vcollegeName\x12\x18\n" +
+	"\aaliases\x18\x03 \x03(\tR\aaliases\x12\x18\n" +
+	"\acountry\x18\x04 \x01(\tR\acountry\x12\x14\n" +
+	"\x05about\x18\x05 \x01(\tR\x05about\x12\x1a\n" +
+	"\blocation\x18\x06 \x01(\tR\blocation\x12\x18\n" +
+	"\asummary\x18\a \x01(\tR\asummary\x12\x1f\n" +
+	"\vug_programs\x18\b \x03(\tR\n" +
+	"ugPrograms\x12\x1f\n" +
+	"\vpg_programs\x18\t \x03(\tR\n" +
+	"pgPrograms\x12!\n" +
+	"\fphd_programs\x18\n" +
+	" \x03(\tR\vphdPrograms\x12$\n" +
+	"\x04fees\x18\v \x01(\v2\x10.college.v1.FeesR\x04fees\x12\"\n" +
+	"\fscholarships\x18\f \x03(\tR\fscholarships\x12I\n" +
+	"\x14student_gender_ratio\x18\r \x01(\v2\x17.college.v1.GenderRatioR\x12studentGenderRatio\x12#\n" +
+	"\rfaculty_staff\x18\x0e \x01(\x05R\ffacultyStaff\x125\n" +
+	"\x16international_students\x18\x0f \x01(\x05R\x15internationalStudents\x12%\n" +
+	"\x0eglobal_ranking\x18\x10 \x01(\tR\rglobalRanking\x120\n" +
+	"\x14global_ranking_value\x18\x11 \x01(\x05R\x12globalRankingValue\x12 \n" +
+	"\vdepartments\x18\x12 \x03(\tR\vdepartments\x12D\n" +
+	"\x12student_statistics\x18\x13 \x03(\v2\x15.college.v1.StatisticR\x11studentStatistics\x12D\n" +
+	"\x12additional_details\x18\x14 \x03(\v2\x15.college.v1.StatisticR\x11additionalDetails\x12\x18\n" +
+	"\asources\x18\x15 \x03(\tR\asources\x12'\n" +
+	"\x0fmanually_edited\x18\x16 \x01(\bR\x0emanuallyEdited\x129\n" +
+	"\n" +
+	"created_at\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x18 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xe2\x01\n" +
+	"\x04Fees\x12\"\n" +
+	"\rug_yearly_min\x18\x01 \x01(\x05R\vugYearlyMin\x12\"\n" +
+	"\rug_yearly_max\x18\x02 \x01(\x05R\vugYearlyMax\x12\"\n" +
+	"\rpg_yearly_min\x18\x03 \x01(\x05R\vpgYearlyMin\x12\"\n" +
+	"\rpg_yearly_max\x18\x04 \x01(\x05R\vpgYearlyMax\x12$\n" +
+	"\x0ephd_yearly_min\x18\x05 \x01(\x05R\fphdYearlyMin\x12$\n" +
+	"\x0ephd_yearly_max\x18\x06 \x01(\x05R\fphdYearlyMax\"c\n" +
+	"\vGenderRatio\x12'\n" +
+	"\x0fmale_percentage\x18\x01 \x01(\x05R\x0emalePercentage\x12+\n" +
+	"\x11female_percentage\x18\x02 \x01(\x05R\x10femalePercentage\"U\n" +
+	"\tStatistic\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x05value\"B\n" +
+	"\x11GetCollegeRequest\x12\x10\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x12\x14\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04nameB\x05\n" +
+	"\x03key\"W\n" +
+	"\x15SearchCollegesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xb4\x01\n" +
+	"\fSearchResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fcollege_name\x18\x02 \x01(\tR\vcollegeName\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x1a\n" +
+	"\blocation\x18\x04 \x01(\tR\blocation\x12%\n" +
+	"\x0eglobal_ranking\x18\x05 \x01(\tR\rglobalRanking\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x01R\x05score\"\x8c\x01\n" +
+	"\x16SearchCollegesResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.college.v1.SearchResultR\aresults\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\"\xa8\x01\n" +
+	"\x1cListCollegesByCountryRequest\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x05 \x01(\bR\n" +
+	"descending\"o\n" +
+	"\x14ListCollegesResponse\x12/\n" +
+	"\bcolleges\x18\x01 \x03(\v2\x13.college.v1.CollegeR\bcolleges\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\";\n" +
+	"\x1bSubscribeNewCollegesRequest\x12\x1c\n" +
+	"\tcountries\x18\x01 \x03(\tR\tcountries2\xe8\x02\n" +
+	"\x0eCollegeService\x12@\n" +
+	"\n" +
+	"GetCollege\x12\x1d.college.v1.GetCollegeRequest\x1a\x13.college.v1.College\x12W\n" +
+	"\x0eSearchColleges\x12!.college.v1.SearchCollegesRequest\x1a\".college.v1.SearchCollegesResponse\x12c\n" +
+	"\x15ListCollegesByCountry\x12(.college.v1.ListCollegesByCountryRequest\x1a .college.v1.ListCollegesResponse\x12V\n" +
+	"\x14SubscribeNewColleges\x12'.college.v1.SubscribeNewCollegesRequest\x1a\x13.college.v1.College0\x01B\x1bZ\x19gobackend/proto/collegepbb\x06proto3"
+
+var (
+	file_proto_collegepb_college_proto_rawDescOnce sync.Once
+	file_proto_collegepb_college_proto_rawDescData []byte
+)
+
+func file_proto_collegepb_college_proto_rawDescGZIP() []byte {
+	file_proto_collegepb_college_proto_rawDescOnce.Do(func() {
+		file_proto_collegepb_college_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_collegepb_college_proto_rawDesc), len(file_proto_collegepb_college_proto_rawDesc)))
+	})
+	return file_proto_collegepb_college_proto_rawDescData
+}
+
+var file_proto_collegepb_college_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_collegepb_college_proto_goTypes = []any{
+	(*College)(nil),                      // 0: college.v1.College
+	(*Fees)(nil),                         // 1: college.v1.Fees
+	(*GenderRatio)(nil),                  // 2: college.v1.GenderRatio
+	(*Statistic)(nil),                    // 3: college.v1.Statistic
+	(*GetCollegeRequest)(nil),            // 4: college.v1.GetCollegeRequest
+	(*SearchCollegesRequest)(nil),        // 5: college.v1.SearchCollegesRequest
+	(*SearchResult)(nil),                 // 6: college.v1.SearchResult
+	(*SearchCollegesResponse)(nil),       // 7: college.v1.SearchCollegesResponse
+	(*ListCollegesByCountryRequest)(nil), // 8: college.v1.ListCollegesByCountryRequest
+	(*ListCollegesResponse)(nil),         // 9: college.v1.ListCollegesResponse
+	(*SubscribeNewCollegesRequest)(nil),  // 10: college.v1.SubscribeNewCollegesRequest
+	(*timestamppb.Timestamp)(nil),        // 11: google.protobuf.Timestamp
+	(*structpb.Value)(nil),               // 12: google.protobuf.Value
+}
+var file_proto_collegepb_college_proto_depIdxs = []int32{
+	1,  // 0: college.v1.College.fees:type_name -> college.v1.Fees
+	2,  // 1: college.v1.College.student_gender_ratio:type_name -> college.v1.GenderRatio
+	3,  // 2: college.v1.College.student_statistics:type_name -> college.v1.Statistic
+	3,  // 3: college.v1.College.additional_details:type_name -> college.v1.Statistic
+	11, // 4: college.v1.College.created_at:type_name -> google.protobuf.Timestamp
+	11, // 5: college.v1.College.updated_at:type_name -> google.protobuf.Timestamp
+	12, // 6: college.v1.Statistic.value:type_name -> google.protobuf.Value
+	6,  // 7: college.v1.SearchCollegesResponse.results:type_name -> college.v1.SearchResult
+	0,  // 8: college.v1.ListCollegesResponse.colleges:type_name -> college.v1.College
+	4,  // 9: college.v1.CollegeService.GetCollege:input_type -> college.v1.GetCollegeRequest
+	5,  // 10: college.v1.CollegeService.SearchColleges:input_type -> college.v1.SearchCollegesRequest
+	8,  // 11: college.v1.CollegeService.ListCollegesByCountry:input_type -> college.v1.ListCollegesByCountryRequest
+	10, // 12: college.v1.CollegeService.SubscribeNewColleges:input_type -> college.v1.SubscribeNewCollegesRequest
+	0,  // 13: college.v1.CollegeService.GetCollege:output_type -> college.v1.College
+	7,  // 14: college.v1.CollegeService.SearchColleges:output_type -> college.v1.SearchCollegesResponse
+	9,  // 15: college.v1.CollegeService.ListCollegesByCountry:output_type -> college.v1.ListCollegesResponse
+	0,  // 16: college.v1.CollegeService.SubscribeNewColleges:output_type -> college.v1.College
+	13, // [13:17] is the sub-list for method output_type
+	9,  // [9:13] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_proto_collegepb_college_proto_init() }
+func file_proto_collegepb_college_proto_init() {
+	if File_proto_collegepb_college_proto != nil {
+		return
+	}
+	file_proto_collegepb_college_proto_msgTypes[4].OneofWrappers = []any{
+		(*GetCollegeRequest_Id)(nil),
+		(*GetCollegeRequest_Name)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_collegepb_college_proto_rawDesc), len(file_proto_collegepb_college_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_collegepb_college_proto_goTypes,
+		DependencyIndexes: file_proto_collegepb_college_proto_depIdxs,
+		MessageInfos:      file_proto_collegepb_college_proto_msgTypes,
+	}.Build()
+	File_proto_collegepb_college_proto = out.File
+	file_proto_collegepb_college_proto_goTypes = nil
+	file_proto_collegepb_college_proto_depIdxs = nil
+}
@@ -0,0 +1,269 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/collegepb/college.proto
+
+package collegepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CollegeService_GetCollege_FullMethodName            = "/college.v1.CollegeService/GetCollege"
+	CollegeService_SearchColleges_FullMethodName        = "/college.v1.CollegeService/SearchColleges"
+	CollegeService_ListCollegesByCountry_FullMethodName = "/college.v1.CollegeService/ListCollegesByCountry"
+	CollegeService_SubscribeNewColleges_FullMethodName  = "/college.v1.CollegeService/SubscribeNewColleges"
+)
+
+// CollegeServiceClient is the client API for CollegeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CollegeService gives internal consumers typed access to college data.
+// Field names and meanings follow models.CollegeStats and the REST API.
+//
+// Regenerate the Go code from the repository root with:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//	  --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+//	  proto/collegepb/college.proto
+type CollegeServiceClient interface {
+	// GetCollege returns a stored college by id, or by name, fetching it from
+	// Gemini when it isn't stored yet.
+	GetCollege(ctx context.Context, in *GetCollegeRequest, opts ...grpc.CallOption) (*College, error)
+	// SearchColleges runs the relevance-ranked full-text search.
+	SearchColleges(ctx context.Context, in *SearchCollegesRequest, opts ...grpc.CallOption) (*SearchCollegesResponse, error)
+	// ListCollegesByCountry returns one page of a country's colleges.
+	ListCollegesByCountry(ctx context.Context, in *ListCollegesByCountryRequest, opts ...grpc.CallOption) (*ListCollegesResponse, error)
+	// SubscribeNewColleges streams colleges as they are added, restored or
+	// moved into one of the requested countries (any country when empty).
+	SubscribeNewColleges(ctx context.Context, in *SubscribeNewCollegesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[College], error)
+}
+
+type collegeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCollegeServiceClient(cc grpc.ClientConnInterface) CollegeServiceClient {
+	return &collegeServiceClient{cc}
+}
+
+func (c *collegeServiceClient) GetCollege(ctx context.Context, in *GetCollegeRequest, opts ...grpc.CallOption) (*College, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(College)
+	err := c.cc.Invoke(ctx, CollegeService_GetCollege_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collegeServiceClient) SearchColleges(ctx context.Context, in *SearchCollegesRequest, opts ...grpc.CallOption) (*SearchCollegesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchCollegesResponse)
+	err := c.cc.Invoke(ctx, CollegeService_SearchColleges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collegeServiceClient) ListCollegesByCountry(ctx context.Context, in *ListCollegesByCountryRequest, opts ...grpc.CallOption) (*ListCollegesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCollegesResponse)
+	err := c.cc.Invoke(ctx, CollegeService_ListCollegesByCountry_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *collegeServiceClient) SubscribeNewColleges(ctx context.Context, in *SubscribeNewCollegesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[College], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CollegeService_ServiceDesc.Streams[0], CollegeService_SubscribeNewColleges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeNewCollegesRequest, College]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CollegeService_SubscribeNewCollegesClient = grpc.ServerStreamingClient[College]
+
+// CollegeServiceServer is the server API for CollegeService service.
+// All implementations must embed UnimplementedCollegeServiceServer
+// for forward compatibility.
+//
+// CollegeService gives internal consumers typed access to college data.
+// Field names and meanings follow models.CollegeStats and the REST API.
+//
+// Regenerate the Go code from the repository root with:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//	  --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+//	  proto/collegepb/college.proto
+type CollegeServiceServer interface {
+	// GetCollege returns a stored college by id, or by name, fetching it from
+	// Gemini when it isn't stored yet.
+	GetCollege(context.Context, *GetCollegeRequest) (*College, error)
+	// SearchColleges runs the relevance-ranked full-text search.
+	SearchColleges(context.Context, *SearchCollegesRequest) (*SearchCollegesResponse, error)
+	// ListCollegesByCountry returns one page of a country's colleges.
+	ListCollegesByCountry(context.Context, *ListCollegesByCountryRequest) (*ListCollegesResponse, error)
+	// SubscribeNewColleges streams colleges as they are added, restored or
+	// moved into one of the requested countries (any country when empty).
+	SubscribeNewColleges(*SubscribeNewCollegesRequest, grpc.ServerStreamingServer[College]) error
+	mustEmbedUnimplementedCollegeServiceServer()
+}
+
+// UnimplementedCollegeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCollegeServiceServer struct{}
+
+func (UnimplementedCollegeServiceServer) GetCollege(context.Context, *GetCollegeRequest) (*College, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollege not implemented")
+}
+func (UnimplementedCollegeServiceServer) SearchColleges(context.Context, *SearchCollegesRequest) (*SearchCollegesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchColleges not implemented")
+}
+func (UnimplementedCollegeServiceServer) ListCollegesByCountry(context.Context, *ListCollegesByCountryRequest) (*ListCollegesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollegesByCountry not implemented")
+}
+func (UnimplementedCollegeServiceServer) SubscribeNewColleges(*SubscribeNewCollegesRequest, grpc.ServerStreamingServer[College]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeNewColleges not implemented")
+}
+func (UnimplementedCollegeServiceServer) mustEmbedUnimplementedCollegeServiceServer() {}
+func (UnimplementedCollegeServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCollegeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CollegeServiceServer will
+// result in compilation errors.
+type UnsafeCollegeServiceServer interface {
+	mustEmbedUnimplementedCollegeServiceServer()
+}
+
+func RegisterCollegeServiceServer(s grpc.ServiceRegistrar, srv CollegeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCollegeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CollegeService_ServiceDesc, srv)
+}
+
+func _CollegeService_GetCollege_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollegeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollegeServiceServer).GetCollege(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollegeService_GetCollege_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollegeServiceServer).GetCollege(ctx, req.(*GetCollegeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollegeService_SearchColleges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchCollegesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollegeServiceServer).SearchColleges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollegeService_SearchColleges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollegeServiceServer).SearchColleges(ctx, req.(*SearchCollegesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollegeService_ListCollegesByCountry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCollegesByCountryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollegeServiceServer).ListCollegesByCountry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CollegeService_ListCollegesByCountry_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollegeServiceServer).ListCollegesByCountry(ctx, req.(*ListCollegesByCountryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CollegeService_SubscribeNewColleges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeNewCollegesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CollegeServiceServer).SubscribeNewColleges(m, &grpc.GenericServerStream[SubscribeNewCollegesRequest, College]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CollegeService_SubscribeNewCollegesServer = grpc.ServerStreamingServer[College]
+
+// CollegeService_ServiceDesc is the grpc.ServiceDesc for CollegeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CollegeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "college.v1.CollegeService",
+	HandlerType: (*CollegeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCollege",
+			Handler:    _CollegeService_GetCollege_Handler,
+		},
+		{
+			MethodName: "SearchColleges",
+			Handler:    _CollegeService_SearchColleges_Handler,
+		},
+		{
+			MethodName: "ListCollegesByCountry",
+			Handler:    _CollegeService_ListCollegesByCountry_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeNewColleges",
+			Handler:       _CollegeService_SubscribeNewColleges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/collegepb/college.proto",
+}
@@ -12,12 +12,35 @@ import (
 func SetupRoutes() *mux.Router {
 	r := mux.NewRouter()
 
-	r.HandleFunc("/api/college-statistics", controllers.GetCollegeStatistics).Methods("GET")
-	r.HandleFunc("/api/countries", controllers.GetCountries).Methods("GET")
-	r.HandleFunc("/api/colleges-by-country", controllers.GetCollegesByCountry).Methods("GET")
-	r.HandleFunc("/api/search", controllers.SearchUniversity).Methods("GET")
-	r.HandleFunc("/api/all-colleges", controllers.GetAllColleges).Methods("GET")
-	r.HandleFunc("/api/health", controllers.HealthCheck).Methods("GET")
+	r.HandleFunc("/api/college-statistics", middleware.ConditionalGet(middleware.CacheRecord, controllers.GetCollegeStatistics)).Methods("GET")
+	r.HandleFunc("/api/countries", middleware.ConditionalGet(middleware.CacheAggregate, controllers.GetCountries)).Methods("GET")
+	r.HandleFunc("/api/colleges-by-country", middleware.ConditionalGet(middleware.CacheList, controllers.GetCollegesByCountry)).Methods("GET")
+	r.HandleFunc("/api/search", middleware.ConditionalGet(middleware.CacheRecord, controllers.SearchUniversity)).Methods("GET")
+	r.HandleFunc("/api/all-colleges", middleware.ConditionalGet(middleware.CacheList, controllers.GetAllColleges)).Methods("GET")
+	r.HandleFunc("/api/health", middleware.NoStore(controllers.HealthCheck)).Methods("GET")
+	r.HandleFunc("/api/compare", middleware.ConditionalGet(middleware.CacheRecord, controllers.CompareColleges)).Methods("GET")
+	r.HandleFunc("/api/analytics/countries", middleware.ConditionalGet(middleware.CacheAggregate, controllers.GetCountryAnalytics)).Methods("GET")
+	r.HandleFunc("/api/analytics/countries/{country}", middleware.ConditionalGet(middleware.CacheAggregate, controllers.GetCountryAnalyticsByName)).Methods("GET")
+	r.HandleFunc("/api/suggest", middleware.ConditionalGet(middleware.CacheList, controllers.SuggestColleges)).Methods("GET")
+	r.HandleFunc("/graphql", controllers.GraphQL).Methods("GET", "POST", "OPTIONS")
+	r.HandleFunc("/api/recommendations", controllers.RecommendColleges).Methods("POST", "OPTIONS")
+	r.HandleFunc("/api/programs", middleware.ConditionalGet(middleware.CacheList, controllers.ListPrograms)).Methods("GET")
+	r.HandleFunc("/api/programs/search", middleware.ConditionalGet(middleware.CacheList, controllers.SearchPrograms)).Methods("GET")
+	r.HandleFunc("/api/colleges", middleware.ConditionalGet(middleware.CacheList, controllers.ListColleges)).Methods("GET")
+	r.HandleFunc("/api/colleges/search", middleware.ConditionalGet(middleware.CacheList, controllers.SearchColleges)).Methods("GET")
+	r.HandleFunc("/api/colleges/{id:[0-9a-fA-F]{24}}", middleware.ConditionalGet(middleware.CacheRecord, controllers.GetCollege)).Methods("GET")
+
+	admin := r.PathPrefix("/api/colleges").Subrouter()
+	admin.Use(middleware.AdminAuthMiddleware)
+	admin.HandleFunc("", controllers.CreateCollege).Methods("POST", "OPTIONS")
+	admin.HandleFunc("/import", controllers.ImportColleges).Methods("POST", "OPTIONS")
+	admin.HandleFunc("/export", controllers.ExportColleges).Methods("GET", "OPTIONS")
+	admin.HandleFunc("/{id:[0-9a-fA-F]{24}}", controllers.ReplaceCollege).Methods("PUT", "OPTIONS")
+	admin.HandleFunc("/{id:[0-9a-fA-F]{24}}", controllers.PatchCollege).Methods("PATCH", "OPTIONS")
+	admin.HandleFunc("/{id:[0-9a-fA-F]{24}}", controllers.DeleteCollege).Methods("DELETE", "OPTIONS")
+	admin.HandleFunc("/{id:[0-9a-fA-F]{24}}/restore", controllers.RestoreCollege).Methods("POST", "OPTIONS")
+
+	r.HandleFunc("/api/events", controllers.StreamEvents).Methods("GET")
 
 	r.HandleFunc("/ws/colleges", controllers.HandleWebSocketColleges)
 	r.HandleFunc("/ws/countries", controllers.HandleWebSocketCountries)
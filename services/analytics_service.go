@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gobackend/config"
+	"gobackend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// analyticsTTL bounds how stale analytics can get if a change notification is missed
+	analyticsTTL = 10 * time.Minute
+	// analyticsTimeout bounds one aggregation run
+	analyticsTimeout = 30 * time.Second
+)
+
+// ErrCountryNotFound is returned when no active college belongs to a country
+var ErrCountryNotFound = errors.New("country not found")
+
+// analyticsCache holds the last computed analytics. mu only guards the
+// fields, never the aggregation, so invalidating it from a write notification
+// doesn't wait on Mongo. generation counts invalidations; a result computed
+// across one is returned to its callers but not cached.
+var analyticsCache struct {
+	mu         sync.Mutex
+	result     *models.AnalyticsResponse
+	expiresAt  time.Time
+	generation uint64
+	compute    singleflight.Group
+}
+
+// InitializeAnalytics drops cached analytics whenever college data changes
+func InitializeAnalytics() {
+	OnCollegeChange(func(CollegeChange) {
+		analyticsCache.mu.Lock()
+		analyticsCache.result = nil
+		analyticsCache.generation++
+		analyticsCache.mu.Unlock()
+	})
+}
+
+// GetCountryAnalytics returns per-country summaries, recomputing them when the
+// cached copy was invalidated by a write or is older than analyticsTTL.
+// Concurrent callers share one aggregation.
+func GetCountryAnalytics() (*models.AnalyticsResponse, error) {
+	analyticsCache.mu.Lock()
+	if analyticsCache.result != nil && time.Now().Before(analyticsCache.expiresAt) {
+		result := analyticsCache.result
+		analyticsCache.mu.Unlock()
+		return result, nil
+	}
+	generation := analyticsCache.generation
+	analyticsCache.mu.Unlock()
+
+	// Keyed by generation so callers arriving after a write don't share a
+	// computation that started before it
+	result, err, _ := analyticsCache.compute.Do(strconv.FormatUint(generation, 10), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), analyticsTimeout)
+		defer cancel()
+
+		started := time.Now()
+		countries, err := aggregateCountryAnalytics(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result := &models.AnalyticsResponse{GeneratedAt: time.Now().UTC(), Countries: countries}
+		log.Printf("📈 Country analytics computed for %d countries (⏱️ %dms)", len(countries), time.Since(started).Milliseconds())
+
+		analyticsCache.mu.Lock()
+		if analyticsCache.generation == generation {
+			analyticsCache.result = result
+			analyticsCache.expiresAt = time.Now().Add(analyticsTTL)
+		}
+		analyticsCache.mu.Unlock()
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*models.AnalyticsResponse), nil
+}
+
+// GetAnalyticsForCountry returns the summary for a single country (case-insensitive)
+func GetAnalyticsForCountry(country string) (*models.CountryAnalytics, time.Time, error) {
+	all, err := GetCountryAnalytics()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	for i := range all.Countries {
+		if strings.EqualFold(all.Countries[i].Country, country) {
+			return &all.Countries[i], all.GeneratedAt, nil
+		}
+	}
+	return nil, time.Time{}, ErrCountryNotFound
+}
+
+// hasFees is true for colleges that reported fees for a level
+func hasFees(level string) bson.M {
+	return bson.M{"$gt": bson.A{"$fees." + level + "_yearly_max", 0}}
+}
+
+func pushIf(condition interface{}, value string) bson.M {
+	return bson.M{"$push": bson.M{"$cond": bson.A{condition, value, "$$REMOVE"}}}
+}
+
+func rankBand(min, max int) bson.M {
+	conditions := bson.A{bson.M{"$gte": bson.A{"$global_ranking_value", min}}}
+	if max > 0 {
+		conditions = append(conditions, bson.M{"$lte": bson.A{"$global_ranking_value", max}})
+	}
+	return bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$and": conditions}, 1, 0}}}
+}
+
+type countryAggregate struct {
+	Name          string    `bson:"name"`
+	Colleges      int       `bson:"colleges"`
+	UGMins        []float64 `bson:"ug_mins"`
+	UGMaxs        []float64 `bson:"ug_maxs"`
+	PGMins        []float64 `bson:"pg_mins"`
+	PGMaxs        []float64 `bson:"pg_maxs"`
+	PhDMins       []float64 `bson:"phd_mins"`
+	PhDMaxs       []float64 `bson:"phd_maxs"`
+	AvgMale       float64   `bson:"avg_male"`
+	AvgFemale     float64   `bson:"avg_female"`
+	International int       `bson:"international"`
+	Top100        int       `bson:"top_100"`
+	Top200        int       `bson:"top_200"`
+	Top500        int       `bson:"top_500"`
+	Top1000       int       `bson:"top_1000"`
+	Beyond        int       `bson:"beyond"`
+	Unranked      int       `bson:"unranked"`
+	Best          *struct {
+		Rank int    `bson:"rank"`
+		Name string `bson:"name"`
+	} `bson:"best"`
+}
+
+func aggregateCountryAnalytics(ctx context.Context) ([]models.CountryAnalytics, error) {
+	reportsGender := bson.M{"$gt": bson.A{
+		bson.M{"$add": bson.A{
+			bson.M{"$ifNull": bson.A{"$student_gender_ratio.male_percentage", 0}},
+			bson.M{"$ifNull": bson.A{"$student_gender_ratio.female_percentage", 0}},
+		}},
+		0,
+	}}
+	ranked := bson.M{"$gt": bson.A{bson.M{"$ifNull": bson.A{"$global_ranking_value", 0}}, 0}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: activeFilter(bson.M{"country": bson.M{"$nin": bson.A{"", nil}}})}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           bson.M{"$toLower": "$country"},
+			"name":          bson.M{"$first": "$country"},
+			"colleges":      bson.M{"$sum": 1},
+			"ug_mins":       pushIf(hasFees("ug"), "$fees.ug_yearly_min"),
+			"ug_maxs":       pushIf(hasFees("ug"), "$fees.ug_yearly_max"),
+			"pg_mins":       pushIf(hasFees("pg"), "$fees.pg_yearly_min"),
+			"pg_maxs":       pushIf(hasFees("pg"), "$fees.pg_yearly_max"),
+			"phd_mins":      pushIf(hasFees("phd"), "$fees.phd_yearly_min"),
+			"phd_maxs":      pushIf(hasFees("phd"), "$fees.phd_yearly_max"),
+			"avg_male":      bson.M{"$avg": bson.M{"$cond": bson.A{reportsGender, "$student_gender_ratio.male_percentage", "$$REMOVE"}}},
+			"avg_female":    bson.M{"$avg": bson.M{"$cond": bson.A{reportsGender, "$student_gender_ratio.female_percentage", "$$REMOVE"}}},
+			"international": bson.M{"$sum": bson.M{"$ifNull": bson.A{"$international_students", 0}}},
+			"top_100":       rankBand(1, 100),
+			"top_200":       rankBand(101, 200),
+			"top_500":       rankBand(201, 500),
+			"top_1000":      rankBand(501, 1000),
+			"beyond":        rankBand(1001, 0),
+			"unranked":      bson.M{"$sum": bson.M{"$cond": bson.A{ranked, 0, 1}}},
+			"best": bson.M{"$min": bson.M{"$cond": bson.A{
+				ranked,
+				// Embedded documents compare field by field in order, so
+				// rank must come first
+				bson.D{{Key: "rank", Value: "$global_ranking_value"}, {Key: "name", Value: "$college_name"}},
+				"$$REMOVE",
+			}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "colleges", Value: -1}, {Key: "name", Value: 1}}}},
+	}
+
+	cursor, err := config.CollegeCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	countries := make([]models.CountryAnalytics, 0)
+	for cursor.Next(ctx) {
+		var agg countryAggregate
+		if err := cursor.Decode(&agg); err != nil {
+			log.Printf("❌ Failed to decode country analytics: %v", err)
+			continue
+		}
+
+		summary := models.CountryAnalytics{
+			Country:                    agg.Name,
+			Colleges:                   agg.Colleges,
+			UGFees:                     summarizeFees(agg.UGMins, agg.UGMaxs),
+			PGFees:                     summarizeFees(agg.PGMins, agg.PGMaxs),
+			PhDFees:                    summarizeFees(agg.PhDMins, agg.PhDMaxs),
+			AverageMalePercentage:      roundTo(agg.AvgMale, 1),
+			AverageFemalePercentage:    roundTo(agg.AvgFemale, 1),
+			TotalInternationalStudents: agg.International,
+			Rankings: models.RankingDistribution{
+				Top100:   agg.Top100,
+				Top200:   agg.Top200,
+				Top500:   agg.Top500,
+				Top1000:  agg.Top1000,
+				Beyond:   agg.Beyond,
+				Unranked: agg.Unranked,
+			},
+		}
+		if agg.Best != nil {
+			summary.Rankings.BestRank = agg.Best.Rank
+			summary.Rankings.BestRankedCollege = agg.Best.Name
+		}
+		countries = append(countries, summary)
+	}
+
+	return countries, cursor.Err()
+}
+
+func summarizeFees(mins, maxs []float64) models.FeeSummary {
+	summary := models.FeeSummary{CollegesReporting: len(maxs)}
+	if len(maxs) == 0 {
+		return summary
+	}
+
+	summary.MedianMin = median(mins)
+	summary.MedianMax = median(maxs)
+	summary.Lowest = int(mins[0])
+	summary.Highest = int(maxs[len(maxs)-1])
+	return summary
+}
+
+// median sorts values in place and returns their median
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+func roundTo(value float64, decimals int) float64 {
+	scale := 1.0
+	for i := 0; i < decimals; i++ {
+		scale *= 10
+	}
+	return float64(int64(value*scale+0.5)) / scale
+}
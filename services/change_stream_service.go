@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gobackend/config"
+	"gobackend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// collegeStreamName identifies the college_details watcher in the
+// change_stream_tokens collection.
+const collegeStreamName = "college_details"
+
+// Server error codes that mean the stored resume token can no longer be used.
+var staleResumeTokenCodes = map[int32]bool{
+	260: true, // InvalidResumeToken
+	280: true, // ChangeStreamFatalError
+	286: true, // ChangeStreamHistoryLost
+}
+
+// Server error codes that mean change streams are not available on this deployment.
+var changeStreamUnsupportedCodes = map[int32]bool{
+	40573: true, // $changeStream is only supported on replica sets
+	115:   true, // CommandNotSupported
+}
+
+// unknownFieldCode is returned by servers before MongoDB 6.0, which have no
+// pre-images, when a change stream asks for fullDocumentBeforeChange.
+const unknownFieldCode = 40415
+
+var changeStreamActive atomic.Bool
+
+// collegeKey remembers where a document lived so deletes, which only carry
+// the document key, can still be routed to the right country.
+type collegeKey struct {
+	Name    string
+	Country string
+	Deleted bool
+}
+
+var (
+	collegeKeys   = make(map[string]collegeKey)
+	collegeKeysMu sync.RWMutex
+)
+
+type collegeChangeEvent struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   struct {
+		ID interface{} `bson:"_id"`
+	} `bson:"documentKey"`
+	FullDocument             *models.CollegeStats `bson:"fullDocument"`
+	FullDocumentBeforeChange *models.CollegeStats `bson:"fullDocumentBeforeChange"`
+}
+
+// ChangeStreamActive reports whether the college_details watcher is running.
+// While it is, WebSocket events are driven by the change stream instead of
+// the request handlers that performed the write.
+func ChangeStreamActive() bool {
+	return changeStreamActive.Load()
+}
+
+// WatchCollegeChanges tails the college_details change stream and translates
+// inserts, updates and deletes into WebSocket events for the affected country.
+// The resume token is persisted after every event so a restart picks up where
+// the previous process stopped. It blocks until ctx is cancelled or change
+// streams turn out to be unsupported (e.g. a standalone mongod).
+func WatchCollegeChanges(ctx context.Context) {
+	if config.CollegeCollection == nil {
+		log.Println("⚠️ Change stream watcher not started: database not connected")
+		return
+	}
+
+	if err := loadCollegeKeys(ctx); err != nil {
+		log.Printf("⚠️ Could not preload college keys: %v", err)
+	}
+
+	preImages := supportsPreImages(ctx)
+
+	backoff := time.Second
+	for ctx.Err() == nil {
+		err := watchOnce(ctx, preImages)
+		changeStreamActive.Store(false)
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) {
+			if changeStreamUnsupportedCodes[cmdErr.Code] {
+				log.Printf("⚠️ Change streams unavailable (%s), falling back to in-process broadcasts", cmdErr.Message)
+				return
+			}
+			if preImages && cmdErr.Code == unknownFieldCode {
+				log.Printf("⚠️ Change stream pre-images unsupported (%s), watching without them", cmdErr.Message)
+				preImages = false
+				backoff = time.Second
+				continue
+			}
+			if staleResumeTokenCodes[cmdErr.Code] {
+				log.Printf("⚠️ Stored resume token rejected (%s), restarting change stream from now", cmdErr.Message)
+				clearResumeToken(ctx)
+				backoff = time.Second
+				continue
+			}
+		}
+
+		if err != nil {
+			log.Printf("❌ Change stream error: %v (retrying in %s)", err, backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+
+	log.Println("🛑 Change stream watcher stopped")
+}
+
+// supportsPreImages reports whether the server is MongoDB 6.0 or later and so
+// accepts fullDocumentBeforeChange. When the version can't be read it assumes
+// so; watchOnce's unknown field error then turns pre-images off.
+func supportsPreImages(ctx context.Context) bool {
+	var info struct {
+		Version      string  `bson:"version"`
+		VersionArray []int32 `bson:"versionArray"`
+	}
+	err := config.CollegeCollection.Database().RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&info)
+	if err != nil || len(info.VersionArray) == 0 {
+		return true
+	}
+	if info.VersionArray[0] < 6 {
+		log.Printf("⚠️ MongoDB %s has no change stream pre-images, watching without them", info.Version)
+		return false
+	}
+	return true
+}
+
+func watchOnce(ctx context.Context, preImages bool) error {
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if preImages {
+		opts.SetFullDocumentBeforeChange(options.WhenAvailable)
+	}
+
+	if token := loadResumeToken(ctx); token != nil {
+		opts.SetStartAfter(token)
+	}
+
+	stream, err := config.CollegeCollection.Watch(ctx, mongo.Pipeline{}, opts)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+
+	changeStreamActive.Store(true)
+	log.Println("👀 Watching college_details change stream")
+
+	for stream.Next(ctx) {
+		var event collegeChangeEvent
+		if err := stream.Decode(&event); err != nil {
+			log.Printf("❌ Failed to decode change event: %v", err)
+		} else {
+			handleCollegeChange(event)
+		}
+
+		saveResumeToken(ctx, stream.ResumeToken())
+
+		if event.OperationType == "invalidate" {
+			return fmt.Errorf("change stream invalidated")
+		}
+	}
+
+	return stream.Err()
+}
+
+func handleCollegeChange(event collegeChangeEvent) {
+	id := formatDocumentID(event.DocumentKey.ID)
+
+	collegeKeysMu.RLock()
+	previous, known := collegeKeys[id]
+	collegeKeysMu.RUnlock()
+
+	if !known && event.FullDocumentBeforeChange != nil {
+		previous = keyFor(*event.FullDocumentBeforeChange)
+		known = true
+	}
+
+	switch event.OperationType {
+	case "insert":
+		if event.FullDocument == nil {
+			return
+		}
+		current := keyFor(*event.FullDocument)
+		rememberCollegeKey(id, current)
+		if current.Deleted {
+			return
+		}
+		notifyStreamedUpsert(event.FullDocument, true)
+		log.Printf("📥 Change stream insert: %s (%s)", current.Name, current.Country)
+		sendCollegeEvent("new_college", *event.FullDocument)
+
+	case "update", "replace":
+		if event.FullDocument == nil {
+			// The document was removed again before the lookup ran; the
+			// matching delete event will follow.
+			return
+		}
+		current := keyFor(*event.FullDocument)
+		rememberCollegeKey(id, current)
+		log.Printf("✏️ Change stream %s: %s (%s)", event.OperationType, current.Name, current.Country)
+
+		wasVisible := known && !previous.Deleted
+		moved := wasVisible && !strings.EqualFold(previous.Country, current.Country)
+
+		switch {
+		case current.Deleted:
+			notifyStreamedDelete(id, current.Country)
+		case moved:
+			notifyStreamedDelete(id, previous.Country)
+			notifyStreamedUpsert(event.FullDocument, true)
+		case known && previous.Deleted:
+			notifyStreamedUpsert(event.FullDocument, true)
+		default:
+			notifyStreamedUpsert(event.FullDocument, false)
+		}
+
+		switch {
+		case current.Deleted && wasVisible:
+			sendCollegeDeleted(id, previous)
+		case current.Deleted:
+			// Still soft deleted; nothing visible changed.
+		case moved:
+			sendCollegeDeleted(id, previous)
+			sendCollegeEvent("new_college", *event.FullDocument)
+		case known && previous.Deleted:
+			sendCollegeEvent("new_college", *event.FullDocument)
+		default:
+			sendCollegeUpdated(event)
+		}
+
+	case "delete":
+		collegeKeysMu.Lock()
+		delete(collegeKeys, id)
+		collegeKeysMu.Unlock()
+
+		if !known {
+			log.Printf("⚠️ Change stream delete for unknown document %s, skipping broadcast", id)
+			notifyStreamedDelete(id, "")
+			return
+		}
+		notifyStreamedDelete(id, previous.Country)
+		log.Printf("🗑️ Change stream delete: %s (%s)", previous.Name, previous.Country)
+		if !previous.Deleted {
+			sendCollegeDeleted(id, previous)
+		}
+	}
+}
+
+func sendCollegeEvent(eventType string, college models.CollegeStats) {
+	broadcastCollegeMessage(college.Country, college.ID.Hex(), map[string]interface{}{
+		"type":       eventType,
+		"college":    CollegePayload(college),
+		"college_id": college.ID.Hex(),
+		"country":    college.Country,
+	})
+}
+
+// sendCollegeUpdated reports an update with a diff when the collection keeps
+// pre-images (changeStreamPreAndPostImages), and without one otherwise.
+func sendCollegeUpdated(event collegeChangeEvent) {
+	if event.FullDocumentBeforeChange == nil {
+		sendCollegeEvent("college_updated", *event.FullDocument)
+		return
+	}
+	if message, ok := collegeUpdatedMessage(*event.FullDocumentBeforeChange, *event.FullDocument); ok {
+		broadcastCollegeMessage(event.FullDocument.Country, event.FullDocument.ID.Hex(), message)
+	}
+}
+
+func sendCollegeDeleted(id string, key collegeKey) {
+	broadcastCollegeMessage(key.Country, id, map[string]interface{}{
+		"type":       "college_deleted",
+		"college":    map[string]interface{}{"id": key.Name, "name": key.Name, "country": key.Country},
+		"college_id": id,
+		"country":    key.Country,
+	})
+}
+
+func keyFor(college models.CollegeStats) collegeKey {
+	return collegeKey{Name: college.CollegeName, Country: college.Country, Deleted: college.DeletedAt != nil}
+}
+
+func rememberCollegeKey(id string, key collegeKey) {
+	collegeKeysMu.Lock()
+	collegeKeys[id] = key
+	collegeKeysMu.Unlock()
+}
+
+func loadCollegeKeys(ctx context.Context) error {
+	cursor, err := config.CollegeCollection.Find(ctx, bson.M{},
+		options.Find().SetProjection(bson.M{"college_name": 1, "country": 1, "deleted_at": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	keys := make(map[string]collegeKey)
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID          interface{} `bson:"_id"`
+			CollegeName string      `bson:"college_name"`
+			Country     string      `bson:"country"`
+			DeletedAt   *time.Time  `bson:"deleted_at"`
+		}
+		if err := cursor.Decode(&doc); err == nil {
+			keys[formatDocumentID(doc.ID)] = collegeKey{Name: doc.CollegeName, Country: doc.Country, Deleted: doc.DeletedAt != nil}
+		}
+	}
+
+	collegeKeysMu.Lock()
+	collegeKeys = keys
+	collegeKeysMu.Unlock()
+
+	return cursor.Err()
+}
+
+func loadResumeToken(ctx context.Context) bson.Raw {
+	if config.StreamTokens == nil {
+		return nil
+	}
+
+	var doc struct {
+		Token bson.Raw `bson:"token"`
+	}
+	if err := config.StreamTokens.FindOne(ctx, bson.M{"_id": collegeStreamName}).Decode(&doc); err != nil {
+		return nil
+	}
+
+	return doc.Token
+}
+
+func saveResumeToken(ctx context.Context, token bson.Raw) {
+	if config.StreamTokens == nil || token == nil {
+		return
+	}
+
+	_, err := config.StreamTokens.UpdateOne(ctx,
+		bson.M{"_id": collegeStreamName},
+		bson.M{"$set": bson.M{"token": token, "updated_at": time.Now()}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		log.Printf("❌ Failed to persist resume token: %v", err)
+	}
+}
+
+func clearResumeToken(ctx context.Context) {
+	if config.StreamTokens == nil {
+		return
+	}
+	config.StreamTokens.DeleteOne(ctx, bson.M{"_id": collegeStreamName})
+}
+
+func formatDocumentID(id interface{}) string {
+	if oid, ok := id.(primitive.ObjectID); ok {
+		return oid.Hex()
+	}
+	return fmt.Sprint(id)
+}
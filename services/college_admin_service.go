@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"gobackend/config"
+	"gobackend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ErrCollegeNotFound = errors.New("college not found")
+	ErrCollegeExists   = errors.New("a college with this name already exists")
+	ErrInvalidID       = errors.New("invalid college id")
+)
+
+// Fields managed by the server that admin payloads may not set.
+var readOnlyCollegeFields = map[string]bool{
+	"id":                   true,
+	"global_ranking_value": true,
+	"manually_edited":      true,
+	"created_at":           true,
+	"updated_at":           true,
+	"deleted_at":           true,
+}
+
+// GetCollegeByID loads a college by its ObjectID hex string. Soft deleted
+// records are only returned when includeDeleted is set.
+func GetCollegeByID(id string, includeDeleted bool) (*models.CollegeStats, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrInvalidID
+	}
+
+	filter := bson.M{"_id": oid}
+	if !includeDeleted {
+		filter = activeFilter(filter)
+	}
+
+	var college models.CollegeStats
+	err = config.CollegeCollection.FindOne(context.TODO(), filter).Decode(&college)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrCollegeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &college, nil
+}
+
+// CreateCollege stores an admin-authored college record. A preset ID is kept,
+// which lets imports restore records under their original id.
+func CreateCollege(stats *models.CollegeStats) (*models.CollegeStats, error) {
+	if errs := stats.Validate(); len(errs) > 0 {
+		return nil, errs
+	}
+
+	if err := ensureNameAvailable(stats.CollegeName, primitive.NilObjectID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if stats.ID.IsZero() {
+		stats.ID = primitive.NewObjectID()
+	}
+	stats.ManuallyEdited = true
+	stats.CreatedAt = now
+	stats.UpdatedAt = now
+	stats.DeletedAt = nil
+
+	if err := insertCollege(stats); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🛠️ Admin created college %s (%s)", stats.CollegeName, stats.ID.Hex())
+	return stats, nil
+}
+
+// insertCollege stores stats as given and announces it unless it is soft
+// deleted. Callers set the id, flags and timestamps.
+func insertCollege(stats *models.CollegeStats) error {
+	stats.SetDerivedFields()
+	if _, err := config.CollegeCollection.InsertOne(context.TODO(), stats); err != nil {
+		return err
+	}
+
+	if stats.DeletedAt == nil {
+		notifyCollegeCreated(stats)
+		BroadcastCollegeEvent("new_college", stats.Country, stats.ID.Hex(), CollegePayload(*stats))
+	}
+	return nil
+}
+
+// ReplaceCollege overwrites every editable field of a college (PUT semantics)
+func ReplaceCollege(id string, stats *models.CollegeStats) (*models.CollegeStats, error) {
+	existing, err := GetCollegeByID(id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return saveAdminEdit(existing, stats)
+}
+
+// PatchCollege applies a partial update to a college. Nested objects such as
+// fees and student_gender_ratio are merged key by key; everything else is replaced.
+func PatchCollege(id string, patch map[string]interface{}) (*models.CollegeStats, error) {
+	existing, err := GetCollegeByID(id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs models.ValidationErrors
+	allowed := editableCollegeFields()
+	for key := range patch {
+		if !allowed[key] {
+			errs = append(errs, models.FieldError{Field: key, Message: "unknown or read-only field"})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	raw, err := json.Marshal(existing)
+	if err != nil {
+		return nil, err
+	}
+	var merged map[string]interface{}
+	if err := json.Unmarshal(raw, &merged); err != nil {
+		return nil, err
+	}
+
+	for key, value := range patch {
+		current, currentIsObject := merged[key].(map[string]interface{})
+		incoming, incomingIsObject := value.(map[string]interface{})
+		if currentIsObject && incomingIsObject {
+			for k, v := range incoming {
+				current[k] = v
+			}
+			continue
+		}
+		merged[key] = value
+	}
+
+	raw, err = json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	var updated models.CollegeStats
+	if err := json.Unmarshal(raw, &updated); err != nil {
+		return nil, models.ValidationErrors{{Field: "body", Message: err.Error()}}
+	}
+
+	return saveAdminEdit(existing, &updated)
+}
+
+// DeleteCollege soft deletes a college so it can be restored later
+func DeleteCollege(id string) error {
+	existing, err := GetCollegeByID(id, false)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	_, err = config.CollegeCollection.UpdateOne(context.TODO(),
+		bson.M{"_id": existing.ID},
+		bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}},
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("🗑️ Admin deleted college %s (%s)", existing.CollegeName, id)
+	notifyCollegeDeleted(existing.ID.Hex(), existing.Country)
+	BroadcastCollegeEvent("college_deleted", existing.Country, existing.ID.Hex(), map[string]interface{}{
+		"id":      existing.CollegeName,
+		"name":    existing.CollegeName,
+		"country": existing.Country,
+	})
+	return nil
+}
+
+// RestoreCollege undoes a soft delete
+func RestoreCollege(id string) (*models.CollegeStats, error) {
+	existing, err := GetCollegeByID(id, true)
+	if err != nil {
+		return nil, err
+	}
+	if existing.DeletedAt == nil {
+		return existing, nil
+	}
+
+	if err := ensureNameAvailable(existing.CollegeName, existing.ID); err != nil {
+		return nil, err
+	}
+
+	existing.DeletedAt = nil
+	existing.UpdatedAt = time.Now().UTC()
+	_, err = config.CollegeCollection.UpdateOne(context.TODO(),
+		bson.M{"_id": existing.ID},
+		bson.M{
+			"$set":   bson.M{"updated_at": existing.UpdatedAt},
+			"$unset": bson.M{"deleted_at": ""},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("♻️ Admin restored college %s (%s)", existing.CollegeName, id)
+	notifyCollegeCreated(existing)
+	BroadcastCollegeEvent("new_college", existing.Country, existing.ID.Hex(), CollegePayload(*existing))
+	return existing, nil
+}
+
+func saveAdminEdit(existing, updated *models.CollegeStats) (*models.CollegeStats, error) {
+	if errs := updated.Validate(); len(errs) > 0 {
+		return nil, errs
+	}
+
+	if !strings.EqualFold(existing.CollegeName, updated.CollegeName) {
+		if err := ensureNameAvailable(updated.CollegeName, existing.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	updated.ID = existing.ID
+	updated.CreatedAt = existing.CreatedAt
+	updated.UpdatedAt = time.Now().UTC()
+	updated.ManuallyEdited = true
+	updated.DeletedAt = nil
+
+	if err := replaceCollegeRecord(existing, updated); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🛠️ Admin updated college %s (%s)", updated.CollegeName, updated.ID.Hex())
+	return updated, nil
+}
+
+// replaceCollegeRecord overwrites existing with updated as given and
+// announces whatever became visible, changed or hidden. Callers set the id,
+// flags and timestamps.
+func replaceCollegeRecord(existing, updated *models.CollegeStats) error {
+	updated.SetDerivedFields()
+
+	_, err := config.CollegeCollection.ReplaceOne(context.TODO(), bson.M{"_id": existing.ID}, updated)
+	if err != nil {
+		return err
+	}
+
+	wasVisible, visible := existing.DeletedAt == nil, updated.DeletedAt == nil
+	moved := !strings.EqualFold(existing.Country, updated.Country)
+	if wasVisible && visible && !moved {
+		notifyCollegeUpserted(updated)
+		BroadcastCollegeUpdated(existing, updated)
+		return nil
+	}
+	if wasVisible {
+		notifyCollegeDeleted(existing.ID.Hex(), existing.Country)
+		BroadcastCollegeEvent("college_deleted", existing.Country, existing.ID.Hex(), map[string]interface{}{
+			"id":      existing.CollegeName,
+			"name":    existing.CollegeName,
+			"country": existing.Country,
+		})
+	}
+	if visible {
+		notifyCollegeCreated(updated)
+		BroadcastCollegeEvent("new_college", updated.Country, updated.ID.Hex(), CollegePayload(*updated))
+	}
+	return nil
+}
+
+// findActiveCollegeByName looks up an active college by exact, case-insensitive name
+func findActiveCollegeByName(name string) (*models.CollegeStats, error) {
+	var college models.CollegeStats
+	err := config.CollegeCollection.FindOne(context.TODO(), activeFilter(bson.M{
+		"college_name": bson.M{"$regex": "^" + regexp.QuoteMeta(strings.TrimSpace(name)) + "$", "$options": "i"},
+	})).Decode(&college)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrCollegeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &college, nil
+}
+
+// ensureNameAvailable rejects names already used by another active college
+func ensureNameAvailable(name string, exceptID primitive.ObjectID) error {
+	filter := activeFilter(bson.M{
+		"college_name": bson.M{"$regex": "^" + regexp.QuoteMeta(strings.TrimSpace(name)) + "$", "$options": "i"},
+	})
+	if !exceptID.IsZero() {
+		filter["_id"] = bson.M{"$ne": exceptID}
+	}
+
+	count, err := config.CollegeCollection.CountDocuments(context.TODO(), filter, options.Count().SetLimit(1))
+	if err != nil {
+		return fmt.Errorf("checking for duplicate college: %w", err)
+	}
+	if count > 0 {
+		return ErrCollegeExists
+	}
+	return nil
+}
+
+// editableCollegeFields lists the JSON keys of CollegeStats an admin may set
+func editableCollegeFields() map[string]bool {
+	fields := make(map[string]bool)
+	t := reflect.TypeOf(models.CollegeStats{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" && !readOnlyCollegeFields[name] {
+			fields[name] = true
+		}
+	}
+	return fields
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"gobackend/config"
+	"gobackend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Change operations delivered to CollegeChange listeners
+const (
+	ChangeUpsert = "upsert"
+	ChangeDelete = "delete"
+)
+
+// CollegeChange describes a write to college_details. College is nil for
+// deletes (including soft deletes). Created marks upserts that made a college
+// visible: inserts, restores and moves to another country. Streamed marks
+// changes read from the change stream; while it runs (see
+// ChangeStreamActive), writes made by this process are reported both when
+// they are made and again from the stream.
+type CollegeChange struct {
+	Op       string
+	ID       string
+	Country  string
+	College  *models.CollegeStats
+	Created  bool
+	Streamed bool
+}
+
+var (
+	changeListeners   []func(CollegeChange)
+	changeListenersMu sync.RWMutex
+)
+
+// OnCollegeChange registers fn to run after every college write, whether made
+// by this process or seen on the change stream. Writes made here while the
+// watcher is running are reported twice, so listeners must be idempotent.
+func OnCollegeChange(fn func(CollegeChange)) {
+	changeListenersMu.Lock()
+	changeListeners = append(changeListeners, fn)
+	changeListenersMu.Unlock()
+}
+
+func notifyCollegeChange(change CollegeChange) {
+	changeListenersMu.RLock()
+	listeners := changeListeners
+	changeListenersMu.RUnlock()
+
+	for _, fn := range listeners {
+		fn(change)
+	}
+}
+
+func notifyCollegeUpserted(college *models.CollegeStats) {
+	notifyCollegeChange(CollegeChange{Op: ChangeUpsert, ID: college.ID.Hex(), Country: college.Country, College: college})
+}
+
+func notifyCollegeCreated(college *models.CollegeStats) {
+	notifyCollegeChange(CollegeChange{Op: ChangeUpsert, ID: college.ID.Hex(), Country: college.Country, College: college, Created: true})
+}
+
+func notifyCollegeDeleted(id, country string) {
+	notifyCollegeChange(CollegeChange{Op: ChangeDelete, ID: id, Country: country})
+}
+
+func notifyStreamedUpsert(college *models.CollegeStats, created bool) {
+	notifyCollegeChange(CollegeChange{Op: ChangeUpsert, ID: college.ID.Hex(), Country: college.Country, College: college, Created: created, Streamed: true})
+}
+
+func notifyStreamedDelete(id, country string) {
+	notifyCollegeChange(CollegeChange{Op: ChangeDelete, ID: id, Country: country, Streamed: true})
+}
+
+// forEachActiveCollege calls fn for every college that isn't soft deleted.
+// In-memory indexes use it to build their initial state.
+func forEachActiveCollege(fn func(*models.CollegeStats)) (int, error) {
+	if config.CollegeCollection == nil {
+		return 0, nil
+	}
+
+	ctx := context.Background()
+	cursor, err := config.CollegeCollection.Find(ctx, activeFilter(bson.M{}))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	count := 0
+	for cursor.Next(ctx) {
+		var college models.CollegeStats
+		if err := cursor.Decode(&college); err == nil {
+			fn(&college)
+			count++
+		}
+	}
+	return count, cursor.Err()
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"gobackend/models"
+)
+
+// Stages reported while a fetch_college command runs, in the order they can
+// occur
+const (
+	FetchQueued     = "queued"
+	FetchCacheHit   = "cache_hit"
+	FetchGenerating = "generating"
+	FetchPartial    = "partial"
+	FetchValidating = "validating"
+	FetchSaved      = "saved"
+)
+
+const (
+	// maxConcurrentGenerations bounds streaming Gemini calls across all
+	// sockets; later fetches wait in the queued stage
+	maxConcurrentGenerations = 4
+	// generationTimeout is how long one streamed generation may take
+	generationTimeout = 60 * time.Second
+)
+
+var generationSlots = make(chan struct{}, maxConcurrentGenerations)
+
+// FetchError is a fetch_college failure with a models.ErrCode* code
+type FetchError struct {
+	Code    string
+	Message string
+	Fields  []models.FieldError
+}
+
+func (e *FetchError) Error() string {
+	return e.Message
+}
+
+// FetchProgress receives each stage of a fetch; data holds stage details
+// such as the partial text for FetchPartial
+type FetchProgress func(stage string, data map[string]interface{})
+
+// FetchCollegeWithProgress resolves collegeName the way ResolveCollege does,
+// but streams the Gemini generation and reports every stage to progress.
+// New colleges are validated before they are saved and broadcast. Errors are
+// always *FetchError.
+func FetchCollegeWithProgress(ctx context.Context, collegeName string, progress FetchProgress) (stats *models.CollegeStats, cached bool, err error) {
+	collegeName = strings.TrimSpace(collegeName)
+	if collegeName == "" || len(collegeName) > 200 {
+		return nil, false, &FetchError{Code: models.ErrCodeValidation, Message: "college_name must be between 1 and 200 characters"}
+	}
+	progress(FetchQueued, map[string]interface{}{"college_name": collegeName})
+
+	stats, err = findStoredCollege(collegeName)
+	if errors.Is(err, ErrCollegeDeleted) {
+		return nil, false, &FetchError{Code: models.ErrCodeGone, Message: "college has been removed"}
+	}
+	if err == nil {
+		progress(FetchCacheHit, map[string]interface{}{"source": "database", "id": stats.ID.Hex()})
+		return stats, true, nil
+	}
+
+	name := normalizeCollegeName(collegeName)
+	stats, found := GetFromCache(name)
+	if found {
+		progress(FetchCacheHit, map[string]interface{}{"source": "memory"})
+	} else {
+		if stats, err = generateCollege(ctx, name, progress); err != nil {
+			return nil, false, err
+		}
+		SaveToCache(name, stats)
+	}
+
+	if err := SaveCollegeToCache(stats); err != nil {
+		// As with ResolveCollege, the data is still worth returning
+		return stats, false, nil
+	}
+	progress(FetchSaved, map[string]interface{}{"id": stats.ID.Hex()})
+	BroadcastNewCollege(stats.Country, stats.ID.Hex(), CollegePayload(*stats))
+	return stats, false, nil
+}
+
+// generateCollege waits for a generation slot, streams the Gemini response
+// as partial chunks and validates the result
+func generateCollege(ctx context.Context, name string, progress FetchProgress) (*models.CollegeStats, error) {
+	select {
+	case generationSlots <- struct{}{}:
+		defer func() { <-generationSlots }()
+	case <-ctx.Done():
+		return nil, &FetchError{Code: models.ErrCodeUnavailable, Message: "fetch cancelled while queued"}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, generationTimeout)
+	defer cancel()
+
+	progress(FetchGenerating, map[string]interface{}{"college_name": name})
+	start := time.Now()
+	received := 0
+	text, err := StreamCollegeTextFromGemini(ctx, name, func(chunk string) {
+		received += len(chunk)
+		progress(FetchPartial, map[string]interface{}{"chunk": chunk, "received": received})
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, &FetchError{Code: models.ErrCodeUnavailable, Message: "Gemini did not finish in time"}
+		}
+		return nil, &FetchError{Code: models.ErrCodeUpstream, Message: err.Error()}
+	}
+
+	progress(FetchValidating, map[string]interface{}{"received": received})
+	stats, err := parseGeminiResponse(text)
+	if err != nil {
+		return nil, &FetchError{Code: models.ErrCodeUpstream, Message: "Gemini returned malformed data"}
+	}
+	if errs := stats.Validate(); len(errs) > 0 {
+		return nil, &FetchError{Code: models.ErrCodeValidation, Message: "Gemini returned data that failed validation", Fields: errs}
+	}
+
+	log.Printf("✅ Streamed data for: %s (⏱️ %dms)", stats.CollegeName, time.Since(start).Milliseconds())
+	return stats, nil
+}
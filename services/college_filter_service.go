@@ -0,0 +1,135 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gobackend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// rangeFilter maps a numeric query parameter onto a comparison against a field
+type rangeFilter struct {
+	Field string
+	Op    string
+	Min   int
+	Max   int
+}
+
+// Fee filters use overlap semantics: ug_fee_min keeps colleges whose range
+// reaches at least that amount, ug_fee_max keeps colleges whose range starts
+// at or below it.
+var collegeRangeFilters = map[string]rangeFilter{
+	"ug_fee_min":        {Field: "fees.ug_yearly_max", Op: "$gte", Max: math.MaxInt32},
+	"ug_fee_max":        {Field: "fees.ug_yearly_min", Op: "$lte", Max: math.MaxInt32},
+	"pg_fee_min":        {Field: "fees.pg_yearly_max", Op: "$gte", Max: math.MaxInt32},
+	"pg_fee_max":        {Field: "fees.pg_yearly_min", Op: "$lte", Max: math.MaxInt32},
+	"phd_fee_min":       {Field: "fees.phd_yearly_max", Op: "$gte", Max: math.MaxInt32},
+	"phd_fee_max":       {Field: "fees.phd_yearly_min", Op: "$lte", Max: math.MaxInt32},
+	"ranking_min":       {Field: "global_ranking_value", Op: "$gte", Min: 1, Max: 100000},
+	"ranking_max":       {Field: "global_ranking_value", Op: "$lte", Min: 1, Max: 100000},
+	"min_faculty":       {Field: "faculty_staff", Op: "$gte", Max: math.MaxInt32},
+	"min_international": {Field: "international_students", Op: "$gte", Max: math.MaxInt32},
+	"max_international": {Field: "international_students", Op: "$lte", Max: math.MaxInt32},
+	"min_female_pct":    {Field: "student_gender_ratio.female_percentage", Op: "$gte", Max: 100},
+	"max_female_pct":    {Field: "student_gender_ratio.female_percentage", Op: "$lte", Max: 100},
+}
+
+// Text filters match case-insensitively; country must match exactly, the
+// others match any entry with a word starting with the value. Those use an
+// anchored regex on the lowercase keys from SetDerivedFields so the index on
+// the keys can serve them.
+var collegeTextFilters = map[string]string{
+	"country":    "country",
+	"program":    "program_keys",
+	"department": "department_keys",
+}
+
+// CollegeFilterParams lists every filter parameter BuildCollegeFilter accepts
+func CollegeFilterParams() []string {
+	params := make([]string, 0, len(collegeRangeFilters)+len(collegeTextFilters))
+	for name := range collegeRangeFilters {
+		params = append(params, name)
+	}
+	for name := range collegeTextFilters {
+		params = append(params, name)
+	}
+	sort.Strings(params)
+	return params
+}
+
+// IsTextCollegeFilter reports whether a filter parameter takes text rather
+// than an integer
+func IsTextCollegeFilter(name string) bool {
+	_, ok := collegeTextFilters[name]
+	return ok
+}
+
+// BuildCollegeFilter translates filter parameters into a Mongo query. Every
+// malformed value is reported; callers are expected to reject parameters not
+// listed by CollegeFilterParams before calling it.
+func BuildCollegeFilter(params map[string]string) (bson.M, models.ValidationErrors) {
+	var errs models.ValidationErrors
+	conditions := bson.A{}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		raw := strings.TrimSpace(params[name])
+
+		if spec, ok := collegeRangeFilters[name]; ok {
+			value, err := strconv.Atoi(raw)
+			if err != nil || value < spec.Min || value > spec.Max {
+				errs = append(errs, models.FieldError{Field: name, Message: fmt.Sprintf("must be an integer between %d and %d", spec.Min, spec.Max)})
+				continue
+			}
+			conditions = append(conditions, bson.M{spec.Field: bson.M{spec.Op: value}})
+			continue
+		}
+
+		if field, ok := collegeTextFilters[name]; ok {
+			if raw == "" || len(raw) > 100 {
+				errs = append(errs, models.FieldError{Field: name, Message: "must be between 1 and 100 characters"})
+				continue
+			}
+			if name == "country" {
+				conditions = append(conditions, bson.M{field: bson.M{"$regex": "^" + regexp.QuoteMeta(raw) + "$", "$options": "i"}})
+				continue
+			}
+			key := models.FilterKey(raw)
+			if key == "" {
+				errs = append(errs, models.FieldError{Field: name, Message: "must contain a letter or digit"})
+				continue
+			}
+			conditions = append(conditions, bson.M{field: bson.M{"$regex": "^" + regexp.QuoteMeta(key)}})
+			continue
+		}
+
+		errs = append(errs, models.FieldError{Field: name, Message: "unknown filter"})
+	}
+
+	if min, max := params["ranking_min"], params["ranking_max"]; min != "" && max != "" {
+		if lo, err1 := strconv.Atoi(min); err1 == nil {
+			if hi, err2 := strconv.Atoi(max); err2 == nil && lo > hi {
+				errs = append(errs, models.FieldError{Field: "ranking_min", Message: "must not be greater than ranking_max"})
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	if len(conditions) == 0 {
+		return bson.M{}, nil
+	}
+	return bson.M{"$and": conditions}, nil
+}
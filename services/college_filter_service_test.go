@@ -0,0 +1,77 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestBuildCollegeFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   bson.M
+	}{
+		{"no filters", nil, bson.M{}},
+		{"range", map[string]string{"ug_fee_max": " 500000 "}, bson.M{"$and": bson.A{
+			bson.M{"fees.ug_yearly_min": bson.M{"$lte": 500000}},
+		}}},
+		{"country is exact and quoted", map[string]string{"country": "U.K."}, bson.M{"$and": bson.A{
+			bson.M{"country": bson.M{"$regex": `^U\.K\.$`, "$options": "i"}},
+		}}},
+		{"program is an anchored key prefix", map[string]string{"program": "Data-Sci"}, bson.M{"$and": bson.A{
+			bson.M{"program_keys": bson.M{"$regex": "^data sci"}},
+		}}},
+		{"department keeps + and #", map[string]string{"department": "C++"}, bson.M{"$and": bson.A{
+			bson.M{"department_keys": bson.M{"$regex": `^c\+\+`}},
+		}}},
+		{"combined in name order", map[string]string{"ranking_min": "1", "min_faculty": "50"}, bson.M{"$and": bson.A{
+			bson.M{"faculty_staff": bson.M{"$gte": 50}},
+			bson.M{"global_ranking_value": bson.M{"$gte": 1}},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := BuildCollegeFilter(tt.params)
+			if len(errs) > 0 {
+				t.Fatalf("BuildCollegeFilter errors: %v", errs)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildCollegeFilter = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCollegeFilterErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]string
+		fields []string
+	}{
+		{"not a number", map[string]string{"min_faculty": "many"}, []string{"min_faculty"}},
+		{"out of range", map[string]string{"ranking_min": "0", "max_female_pct": "101"}, []string{"max_female_pct", "ranking_min"}},
+		{"empty text", map[string]string{"department": " "}, []string{"department"}},
+		{"punctuation only", map[string]string{"program": "--"}, []string{"program"}},
+		{"inverted ranking", map[string]string{"ranking_min": "200", "ranking_max": "100"}, []string{"ranking_min"}},
+		{"unknown", map[string]string{"colour": "red"}, []string{"colour"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, errs := BuildCollegeFilter(tt.params)
+			if filter != nil {
+				t.Errorf("filter = %v, want nil", filter)
+			}
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("error fields = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gobackend/config"
+	"gobackend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	ViewSummary = "summary"
+	ViewFull    = "full"
+
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// ErrInvalidCursor is returned when a pagination cursor can't be decoded or
+// was issued for a different sort order.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// SortableCollegeFields maps the sort parameter accepted by list endpoints to
+// the document field it orders by.
+var SortableCollegeFields = map[string]string{
+	"college_name":           "college_name",
+	"country":                "country",
+	"faculty_staff":          "faculty_staff",
+	"international_students": "international_students",
+	"ug_fee":                 "fees.ug_yearly_min",
+	"pg_fee":                 "fees.pg_yearly_min",
+	"phd_fee":                "fees.phd_yearly_min",
+	"ranking":                "global_ranking_value",
+	"created_at":             "created_at",
+	"updated_at":             "updated_at",
+}
+
+// summaryProjection loads only what models.CollegeListItem needs
+var summaryProjection = bson.M{
+	"college_name":           1,
+	"country":                1,
+	"location":               1,
+	"global_ranking":         1,
+	"global_ranking_value":   1,
+	"fees":                   1,
+	"student_gender_ratio":   1,
+	"faculty_staff":          1,
+	"international_students": 1,
+	"student_statistics":     1,
+}
+
+// ListOptions controls a paginated college listing. Fields, when set, loads
+// only those top-level fields (plus _id and the sort field) regardless of View.
+type ListOptions struct {
+	Country string
+	Filter  bson.M
+	Sort    string
+	Desc    bool
+	Limit   int
+	Cursor  string
+	View    string
+	Fields  []string
+}
+
+// CollegePage is one page of colleges plus the cursor for the next one
+type CollegePage struct {
+	Colleges   []models.CollegeStats
+	NextCursor string
+}
+
+// pageCursor is the keyset position encoded into the opaque cursor string
+type pageCursor struct {
+	Sort  string        `bson:"s"`
+	Desc  bool          `bson:"d"`
+	Value bson.RawValue `bson:"v"`
+	ID    bson.RawValue `bson:"id"`
+}
+
+// ListColleges returns one page of active colleges using keyset pagination
+// on (sort field, _id), so deep pages cost the same as the first one.
+func ListColleges(opts ListOptions) (*CollegePage, error) {
+	if opts.Sort == "" {
+		opts.Sort = "college_name"
+	}
+	field, ok := SortableCollegeFields[opts.Sort]
+	if !ok {
+		return nil, fmt.Errorf("cannot sort by %q", opts.Sort)
+	}
+	if opts.Limit <= 0 || opts.Limit > MaxPageLimit {
+		opts.Limit = DefaultPageLimit
+	}
+
+	filter := bson.M{}
+	for k, v := range opts.Filter {
+		filter[k] = v
+	}
+	if opts.Country != "" {
+		filter["country"] = bson.M{"$regex": "^" + regexp.QuoteMeta(opts.Country) + "$", "$options": "i"}
+	}
+	filter = activeFilter(filter)
+
+	if opts.Cursor != "" {
+		position, err := decodePageCursor(opts.Cursor)
+		if err != nil || position.Sort != opts.Sort || position.Desc != opts.Desc {
+			return nil, ErrInvalidCursor
+		}
+		filter = bson.M{"$and": bson.A{filter, keysetFilter(field, opts.Desc, position)}}
+	}
+
+	direction := 1
+	if opts.Desc {
+		direction = -1
+	}
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}}).
+		SetLimit(int64(opts.Limit + 1))
+	switch {
+	case len(opts.Fields) > 0:
+		projection := bson.M{field: 1}
+		for _, name := range opts.Fields {
+			if top := strings.SplitN(name, ".", 2)[0]; top != "id" {
+				projection[top] = 1
+			}
+		}
+		findOpts.SetProjection(projection)
+	case opts.View != ViewFull:
+		findOpts.SetProjection(summaryProjection)
+	}
+
+	cursor, err := config.CollegeCollection.Find(context.TODO(), filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	page := &CollegePage{Colleges: make([]models.CollegeStats, 0, opts.Limit)}
+	var last bson.Raw
+	for cursor.Next(context.TODO()) {
+		if len(page.Colleges) == opts.Limit {
+			// The extra document only tells us another page exists.
+			page.NextCursor = encodePageCursor(opts.Sort, opts.Desc, field, last)
+			break
+		}
+
+		var college models.CollegeStats
+		if err := cursor.Decode(&college); err != nil {
+			continue
+		}
+		page.Colleges = append(page.Colleges, college)
+		last = append(bson.Raw(nil), cursor.Current...)
+	}
+
+	return page, cursor.Err()
+}
+
+// keysetFilter matches documents strictly after position in the requested
+// order. Missing or null sort values sort before everything ascending and
+// after everything descending, which needs its own branches.
+func keysetFilter(field string, desc bool, position pageCursor) bson.M {
+	isNull := position.Value.Type == bson.TypeNull || position.Value.Type == 0
+
+	switch {
+	case !desc && isNull:
+		return bson.M{"$or": bson.A{
+			bson.M{field: nil, "_id": bson.M{"$gt": position.ID}},
+			bson.M{field: bson.M{"$ne": nil}},
+		}}
+	case !desc:
+		return bson.M{"$or": bson.A{
+			bson.M{field: bson.M{"$gt": position.Value}},
+			bson.M{field: position.Value, "_id": bson.M{"$gt": position.ID}},
+		}}
+	case isNull:
+		return bson.M{field: nil, "_id": bson.M{"$lt": position.ID}}
+	default:
+		return bson.M{"$or": bson.A{
+			bson.M{field: bson.M{"$lt": position.Value}},
+			bson.M{field: position.Value, "_id": bson.M{"$lt": position.ID}},
+			bson.M{field: nil},
+		}}
+	}
+}
+
+func encodePageCursor(sort string, desc bool, field string, doc bson.Raw) string {
+	value, err := doc.LookupErr(strings.Split(field, ".")...)
+	if err != nil {
+		value = bson.RawValue{Type: bson.TypeNull}
+	}
+
+	raw, err := bson.Marshal(pageCursor{Sort: sort, Desc: desc, Value: value, ID: doc.Lookup("_id")})
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodePageCursor(cursor string) (pageCursor, error) {
+	var position pageCursor
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return position, err
+	}
+	err = bson.Unmarshal(raw, &position)
+	return position, err
+}
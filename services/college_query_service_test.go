@@ -0,0 +1,96 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPageCursorRoundTrip(t *testing.T) {
+	id := primitive.NewObjectID()
+	doc, err := bson.Marshal(bson.M{"_id": id, "fees": bson.M{"ug_yearly_min": 250000}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		sort     string
+		field    string
+		desc     bool
+		wantNull bool
+	}{
+		{"nested field", "ug_fee", "fees.ug_yearly_min", true, false},
+		{"missing field", "faculty_staff", "faculty_staff", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			position, err := decodePageCursor(encodePageCursor(tt.sort, tt.desc, tt.field, doc))
+			if err != nil {
+				t.Fatalf("decodePageCursor: %v", err)
+			}
+			if position.Sort != tt.sort || position.Desc != tt.desc {
+				t.Errorf("position = %s/%v, want %s/%v", position.Sort, position.Desc, tt.sort, tt.desc)
+			}
+			if got := position.ID.ObjectID(); got != id {
+				t.Errorf("ID = %s, want %s", got.Hex(), id.Hex())
+			}
+			if tt.wantNull {
+				if position.Value.Type != bson.TypeNull {
+					t.Errorf("Value type = %s, want null", position.Value.Type)
+				}
+			} else if got := position.Value.Int32(); got != 250000 {
+				t.Errorf("Value = %d, want 250000", got)
+			}
+		})
+	}
+}
+
+func TestDecodePageCursorRejectsGarbage(t *testing.T) {
+	for _, cursor := range []string{"not base64!", "aGVsbG8"} {
+		if _, err := decodePageCursor(cursor); err == nil {
+			t.Errorf("decodePageCursor(%q) succeeded", cursor)
+		}
+	}
+}
+
+func TestKeysetFilter(t *testing.T) {
+	oid := primitive.NewObjectID()
+	id := bson.RawValue{Type: bson.TypeObjectID, Value: oid[:]}
+	_, raw, _ := bson.MarshalValue(int32(10))
+	value := bson.RawValue{Type: bson.TypeInt32, Value: raw}
+	null := bson.RawValue{Type: bson.TypeNull}
+
+	tests := []struct {
+		name     string
+		desc     bool
+		position pageCursor
+		want     bson.M
+	}{
+		{"ascending", false, pageCursor{Value: value, ID: id}, bson.M{"$or": bson.A{
+			bson.M{"f": bson.M{"$gt": value}},
+			bson.M{"f": value, "_id": bson.M{"$gt": id}},
+		}}},
+		{"ascending from null", false, pageCursor{Value: null, ID: id}, bson.M{"$or": bson.A{
+			bson.M{"f": nil, "_id": bson.M{"$gt": id}},
+			bson.M{"f": bson.M{"$ne": nil}},
+		}}},
+		{"descending", true, pageCursor{Value: value, ID: id}, bson.M{"$or": bson.A{
+			bson.M{"f": bson.M{"$lt": value}},
+			bson.M{"f": value, "_id": bson.M{"$lt": id}},
+			bson.M{"f": nil},
+		}}},
+		{"descending from missing", true, pageCursor{ID: id}, bson.M{"f": nil, "_id": bson.M{"$lt": id}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keysetFilter("f", tt.desc, tt.position); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("keysetFilter = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,19 +2,43 @@ package services
 
 import (
 	"context"
+	"errors"
 	"log"
+	"regexp"
+	"time"
 
 	"gobackend/config"
 	"gobackend/models"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ErrCollegeDeleted is returned when the requested college exists but was soft deleted
+var ErrCollegeDeleted = errors.New("college has been deleted")
+
+// activeFilter restricts filter to colleges that have not been soft deleted
+func activeFilter(filter bson.M) bson.M {
+	filter["deleted_at"] = nil
+	return filter
+}
+
+// GetCollegeFromCache finds the active college named collegeName. A deleted
+// college can share its name with an active one that was created later, so
+// ErrCollegeDeleted is only returned when no active college has the name.
 func GetCollegeFromCache(collegeName string) (*models.CollegeStats, error) {
-	var cachedResult models.CollegeStats
-	err := config.CollegeCollection.FindOne(context.TODO(), bson.M{
-		"college_name": bson.M{"$regex": "^" + collegeName + "$", "$options": "i"},
-	}).Decode(&cachedResult)
+	byName := func() bson.M {
+		return bson.M{"college_name": bson.M{"$regex": "^" + regexp.QuoteMeta(collegeName) + "$", "$options": "i"}}
+	}
 
+	var cachedResult models.CollegeStats
+	err := config.CollegeCollection.FindOne(context.TODO(), activeFilter(byName())).Decode(&cachedResult)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if config.CollegeCollection.FindOne(context.TODO(), byName()).Err() == nil {
+			return nil, ErrCollegeDeleted
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -23,7 +47,59 @@ func GetCollegeFromCache(collegeName string) (*models.CollegeStats, error) {
 	return &cachedResult, nil
 }
 
+// ResolveCollege returns the stored college with this name, fetching it from
+// Gemini and saving it when it isn't stored yet. cached reports whether the
+// record came from MongoDB.
+func ResolveCollege(collegeName string) (stats *models.CollegeStats, cached bool, err error) {
+	stats, err = findStoredCollege(collegeName)
+	if err == nil {
+		return stats, true, nil
+	}
+	if errors.Is(err, ErrCollegeDeleted) {
+		return nil, false, err
+	}
+
+	log.Println("🔄 Calling Gemini API directly...")
+	stats, err = FetchCollegeDataFromGemini(collegeName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := SaveCollegeToCache(stats); err == nil {
+		BroadcastNewCollege(stats.Country, stats.ID.Hex(), CollegePayload(*stats))
+	}
+
+	return stats, false, nil
+}
+
+// findStoredCollege looks collegeName up in MongoDB by exact name, then by a
+// close match to a stored name or alias. It returns ErrCollegeNotFound when
+// the college has to be fetched from Gemini.
+func findStoredCollege(collegeName string) (*models.CollegeStats, error) {
+	stats, err := GetCollegeFromCache(collegeName)
+	if err == nil || errors.Is(err, ErrCollegeDeleted) {
+		return stats, err
+	}
+
+	// A close match to a stored name or alias is most likely a typo
+	if id, ok := CorrectCollegeName(collegeName); ok {
+		if stats, err := GetCollegeByID(id, false); err == nil {
+			log.Printf("Resolved %q to stored college %s", collegeName, stats.CollegeName)
+			return stats, nil
+		}
+	}
+	return nil, ErrCollegeNotFound
+}
+
 func SaveCollegeToCache(stats *models.CollegeStats) error {
+	now := time.Now().UTC()
+	if stats.ID.IsZero() {
+		stats.ID = primitive.NewObjectID()
+	}
+	stats.CreatedAt = now
+	stats.UpdatedAt = now
+	stats.SetDerivedFields()
+
 	_, err := config.CollegeCollection.InsertOne(context.TODO(), stats)
 	if err != nil {
 		log.Printf("Cache store failed: %v", err)
@@ -31,26 +107,65 @@ func SaveCollegeToCache(stats *models.CollegeStats) error {
 	}
 
 	log.Println("Cached in MongoDB")
+	notifyCollegeCreated(stats)
 	return nil
 }
 
+// UpdateCollegeCache overwrites a Gemini-sourced record with fresh data.
+// Manually edited and deleted records are never touched.
 func UpdateCollegeCache(collegeName string, stats *models.CollegeStats) error {
-	_, err := config.CollegeCollection.UpdateOne(
-		context.TODO(),
-		bson.M{"college_name": bson.M{"$regex": "^" + collegeName + "$", "$options": "i"}},
-		bson.M{"$set": stats},
-	)
+	fresh := *stats
+	fresh.ID = primitive.NilObjectID
+	fresh.CreatedAt = time.Time{}
+	fresh.UpdatedAt = time.Now().UTC()
+	fresh.SetDerivedFields()
+
+	// $set skips omitempty fields, so a college that is no longer ranked
+	// needs its old ranking value removed explicitly. Aliases are curated by
+	// admins and are kept when Gemini returns none.
+	update := bson.M{"$set": fresh}
+	if fresh.GlobalRankingValue == 0 {
+		update["$unset"] = bson.M{"global_ranking_value": ""}
+	}
 
+	var previous models.CollegeStats
+	err := config.CollegeCollection.FindOneAndUpdate(
+		context.TODO(),
+		activeFilter(bson.M{
+			"college_name":    bson.M{"$regex": "^" + regexp.QuoteMeta(collegeName) + "$", "$options": "i"},
+			"manually_edited": bson.M{"$ne": true},
+		}),
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.Before),
+	).Decode(&previous)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		log.Printf("Cache update skipped for %s: no refreshable record", collegeName)
+		return nil
+	}
 	if err != nil {
 		log.Printf("Cache update failed: %v", err)
 		return err
 	}
 
+	var updated models.CollegeStats
+	if err := config.CollegeCollection.FindOne(context.TODO(), bson.M{"_id": previous.ID}).Decode(&updated); err != nil {
+		log.Printf("Cache updated for %s but re-reading it failed: %v", collegeName, err)
+		return err
+	}
+
 	log.Printf("Cache updated for %s", collegeName)
+	notifyCollegeUpserted(&updated)
+	BroadcastCollegeUpdated(&previous, &updated)
 	return nil
 }
 
 func CompareAndUpdateCache(collegeName string, cachedData models.CollegeStats) {
+	if cachedData.ManuallyEdited {
+		log.Printf("Background: %s was edited manually, skipping Gemini refresh", collegeName)
+		return
+	}
+
 	log.Printf("Background: Fetching fresh data for %s from Gemini", collegeName)
 
 	freshStats, err := FetchCollegeDataFromGemini(collegeName)
@@ -83,9 +198,9 @@ func CompareAndUpdateCache(collegeName string, cachedData models.CollegeStats) {
 
 func SearchUniversityByName(name string) (*models.CollegeStats, error) {
 	var result models.CollegeStats
-	err := config.CollegeCollection.FindOne(context.TODO(), bson.M{
+	err := config.CollegeCollection.FindOne(context.TODO(), activeFilter(bson.M{
 		"college_name": bson.M{"$regex": name, "$options": "i"},
-	}).Decode(&result)
+	})).Decode(&result)
 
 	if err != nil {
 		return nil, err
@@ -95,7 +210,7 @@ func SearchUniversityByName(name string) (*models.CollegeStats, error) {
 }
 
 func GetAllColleges() ([]models.CollegeStats, error) {
-	cursor, err := config.CollegeCollection.Find(context.TODO(), bson.M{})
+	cursor, err := config.CollegeCollection.Find(context.TODO(), activeFilter(bson.M{}))
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +225,7 @@ func GetAllColleges() ([]models.CollegeStats, error) {
 }
 
 func GetDistinctCountries() ([]interface{}, error) {
-	countries, err := config.CollegeCollection.Distinct(context.TODO(), "country", bson.M{})
+	countries, err := config.CollegeCollection.Distinct(context.TODO(), "country", activeFilter(bson.M{}))
 	if err != nil {
 		return nil, err
 	}
@@ -119,9 +234,9 @@ func GetDistinctCountries() ([]interface{}, error) {
 }
 
 func GetCollegesByCountry(country string) ([]models.CollegeStats, error) {
-	cursor, err := config.CollegeCollection.Find(context.TODO(), bson.M{
+	cursor, err := config.CollegeCollection.Find(context.TODO(), activeFilter(bson.M{
 		"country": bson.M{"$regex": "^" + country + "$", "$options": "i"},
-	})
+	}))
 	if err != nil {
 		return nil, err
 	}
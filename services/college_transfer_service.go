@@ -0,0 +1,546 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gobackend/config"
+	"gobackend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+// Separators used to flatten lists into a single CSV cell. Statistics are
+// written as "Category=Value" pairs.
+const (
+	csvListSeparator = "|"
+	csvPairSeparator = "="
+)
+
+// ErrUnsupportedFormat is returned for formats other than jsonl and csv
+var ErrUnsupportedFormat = errors.New("unsupported format, expected jsonl or csv")
+
+// collegeCSVColumns is the flattened CSV layout, in export order
+var collegeCSVColumns = []string{
+	"id",
+	"college_name",
+	"aliases",
+	"country",
+	"about",
+	"location",
+	"summary",
+	"ug_programs",
+	"pg_programs",
+	"phd_programs",
+	"fees.ug_yearly_min",
+	"fees.ug_yearly_max",
+	"fees.pg_yearly_min",
+	"fees.pg_yearly_max",
+	"fees.phd_yearly_min",
+	"fees.phd_yearly_max",
+	"scholarships",
+	"student_gender_ratio.male_percentage",
+	"student_gender_ratio.female_percentage",
+	"faculty_staff",
+	"international_students",
+	"global_ranking",
+	"departments",
+	"student_statistics",
+	"additional_details",
+	"sources",
+	"manually_edited",
+	"created_at",
+	"updated_at",
+	"deleted_at",
+}
+
+// ImportRowError describes why a single row of an import was rejected
+type ImportRowError struct {
+	Row         int                 `json:"row"`
+	CollegeName string              `json:"college_name,omitempty"`
+	Error       string              `json:"error"`
+	Fields      []models.FieldError `json:"fields,omitempty"`
+}
+
+// ImportReport summarises the outcome of an import
+type ImportReport struct {
+	Format   string           `json:"format"`
+	Total    int              `json:"total"`
+	Inserted int              `json:"inserted"`
+	Updated  int              `json:"updated"`
+	Failed   int              `json:"failed"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+// ExportFilter selects the colleges included in an export
+type ExportFilter struct {
+	Country        string
+	Name           string
+	UpdatedSince   time.Time
+	IncludeDeleted bool
+}
+
+// ImportColleges reads colleges in the given format and upserts them one row
+// at a time: rows matching an existing id or name update that record, other
+// rows are inserted. Invalid rows are reported and skipped. Rows keep their
+// manually_edited flag and timestamps, so an export imports back unchanged.
+func ImportColleges(r io.Reader, format string) (*ImportReport, error) {
+	report := &ImportReport{Format: format, Errors: []ImportRowError{}}
+
+	handleRow := func(row int, stats *models.CollegeStats, parseErr error) {
+		report.Total++
+		if parseErr == nil {
+			var inserted bool
+			inserted, parseErr = importCollege(stats)
+			if parseErr == nil {
+				if inserted {
+					report.Inserted++
+				} else {
+					report.Updated++
+				}
+				return
+			}
+		}
+
+		report.Failed++
+		rowErr := ImportRowError{Row: row, Error: parseErr.Error()}
+		if stats != nil {
+			rowErr.CollegeName = stats.CollegeName
+		}
+		var validationErrs models.ValidationErrors
+		if errors.As(parseErr, &validationErrs) {
+			rowErr.Error = "validation failed"
+			rowErr.Fields = validationErrs
+		}
+		report.Errors = append(report.Errors, rowErr)
+	}
+
+	var err error
+	switch format {
+	case FormatJSONL:
+		err = readCollegesJSONL(r, handleRow)
+	case FormatCSV:
+		err = readCollegesCSV(r, handleRow)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return report, err
+	}
+
+	log.Printf("📥 Imported %s: %d inserted, %d updated, %d failed", format, report.Inserted, report.Updated, report.Failed)
+	return report, nil
+}
+
+// importCollege stores one row. An import never deletes or restores an
+// existing record: it keeps that record's deleted state, and rows exported as
+// deleted only match by id. Those that match nothing are inserted still
+// deleted.
+func importCollege(stats *models.CollegeStats) (inserted bool, err error) {
+	if errs := stats.Validate(); len(errs) > 0 {
+		return false, errs
+	}
+
+	var existing *models.CollegeStats
+	if !stats.ID.IsZero() {
+		existing, err = GetCollegeByID(stats.ID.Hex(), true)
+		if err != nil && !errors.Is(err, ErrCollegeNotFound) {
+			return false, err
+		}
+	}
+	if existing == nil && stats.DeletedAt == nil {
+		existing, err = findActiveCollegeByName(stats.CollegeName)
+		if err != nil && !errors.Is(err, ErrCollegeNotFound) {
+			return false, err
+		}
+	}
+
+	if stats.UpdatedAt.IsZero() {
+		stats.UpdatedAt = time.Now().UTC()
+	}
+
+	if existing != nil {
+		stats.ID = existing.ID
+		stats.DeletedAt = existing.DeletedAt
+		if stats.CreatedAt.IsZero() {
+			stats.CreatedAt = existing.CreatedAt
+		}
+		if stats.DeletedAt == nil && !strings.EqualFold(existing.CollegeName, stats.CollegeName) {
+			if err := ensureNameAvailable(stats.CollegeName, existing.ID); err != nil {
+				return false, err
+			}
+		}
+		return false, replaceCollegeRecord(existing, stats)
+	}
+
+	if stats.DeletedAt == nil {
+		if err := ensureNameAvailable(stats.CollegeName, primitive.NilObjectID); err != nil {
+			return false, err
+		}
+	}
+	if stats.ID.IsZero() {
+		stats.ID = primitive.NewObjectID()
+	}
+	if stats.CreatedAt.IsZero() {
+		stats.CreatedAt = stats.UpdatedAt
+	}
+	return true, insertCollege(stats)
+}
+
+func readCollegesJSONL(r io.Reader, handleRow func(int, *models.CollegeStats, error)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		// Reject unknown fields like the admin endpoints do, so a typo
+		// doesn't silently drop data
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.DisallowUnknownFields()
+
+		var stats models.CollegeStats
+		if err := decoder.Decode(&stats); err != nil {
+			handleRow(line, nil, fmt.Errorf("invalid JSON: %w", err))
+			continue
+		}
+		handleRow(line, &stats, nil)
+	}
+
+	return scanner.Err()
+}
+
+func readCollegesCSV(r io.Reader, handleRow func(int, *models.CollegeStats, error)) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading CSV header: %w", err)
+	}
+
+	known := make(map[string]bool, len(collegeCSVColumns))
+	for _, column := range collegeCSVColumns {
+		known[column] = true
+	}
+	for i, column := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		if !known[header[i]] {
+			return fmt.Errorf("unknown CSV column %q", header[i])
+		}
+	}
+
+	row := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		row++
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				handleRow(row, nil, err)
+				continue
+			}
+			return err
+		}
+
+		values := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				values[column] = strings.TrimSpace(record[i])
+			}
+		}
+
+		stats, err := collegeFromCSV(values)
+		handleRow(row, stats, err)
+	}
+}
+
+func collegeFromCSV(values map[string]string) (*models.CollegeStats, error) {
+	stats := &models.CollegeStats{
+		CollegeName:   values["college_name"],
+		Aliases:       splitCSVList(values["aliases"]),
+		Country:       values["country"],
+		About:         values["about"],
+		Location:      values["location"],
+		Summary:       values["summary"],
+		GlobalRanking: values["global_ranking"],
+		UGPrograms:    splitCSVList(values["ug_programs"]),
+		PGPrograms:    splitCSVList(values["pg_programs"]),
+		PhDPrograms:   splitCSVList(values["phd_programs"]),
+		Scholarships:  splitCSVList(values["scholarships"]),
+		Departments:   splitCSVList(values["departments"]),
+		Sources:       splitCSVList(values["sources"]),
+	}
+
+	if id := values["id"]; id != "" {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return stats, fmt.Errorf("invalid id %q", id)
+		}
+		stats.ID = oid
+	}
+
+	var errs models.ValidationErrors
+	intField := func(column string, target *int) {
+		raw := values[column]
+		if raw == "" {
+			return
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			errs = append(errs, models.FieldError{Field: column, Message: fmt.Sprintf("%q is not an integer", raw)})
+			return
+		}
+		*target = n
+	}
+
+	intField("fees.ug_yearly_min", &stats.Fees.UGYearlyMin)
+	intField("fees.ug_yearly_max", &stats.Fees.UGYearlyMax)
+	intField("fees.pg_yearly_min", &stats.Fees.PGYearlyMin)
+	intField("fees.pg_yearly_max", &stats.Fees.PGYearlyMax)
+	intField("fees.phd_yearly_min", &stats.Fees.PhDYearlyMin)
+	intField("fees.phd_yearly_max", &stats.Fees.PhDYearlyMax)
+	intField("student_gender_ratio.male_percentage", &stats.StudentGenderRatio.MalePercentage)
+	intField("student_gender_ratio.female_percentage", &stats.StudentGenderRatio.FemalePercentage)
+	intField("faculty_staff", &stats.FacultyStaff)
+	intField("international_students", &stats.InternationalStudents)
+
+	var err error
+	if stats.StudentStatistics, err = parseCSVStatistics(values["student_statistics"]); err != nil {
+		errs = append(errs, models.FieldError{Field: "student_statistics", Message: err.Error()})
+	}
+	if stats.AdditionalDetails, err = parseCSVStatistics(values["additional_details"]); err != nil {
+		errs = append(errs, models.FieldError{Field: "additional_details", Message: err.Error()})
+	}
+
+	if raw := values["manually_edited"]; raw != "" {
+		if stats.ManuallyEdited, err = strconv.ParseBool(raw); err != nil {
+			errs = append(errs, models.FieldError{Field: "manually_edited", Message: fmt.Sprintf("%q is not true or false", raw)})
+		}
+	}
+	timeField := func(column string) *time.Time {
+		raw := values[column]
+		if raw == "" {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			errs = append(errs, models.FieldError{Field: column, Message: fmt.Sprintf("%q is not an RFC 3339 time", raw)})
+			return nil
+		}
+		t = t.UTC()
+		return &t
+	}
+	if t := timeField("created_at"); t != nil {
+		stats.CreatedAt = *t
+	}
+	if t := timeField("updated_at"); t != nil {
+		stats.UpdatedAt = *t
+	}
+	stats.DeletedAt = timeField("deleted_at")
+
+	if len(errs) > 0 {
+		return stats, errs
+	}
+	return stats, nil
+}
+
+func splitCSVList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, csvListSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseCSVStatistics(raw string) ([]models.StatisticItem, error) {
+	var stats []models.StatisticItem
+	for _, pair := range splitCSVList(raw) {
+		category, value, ok := strings.Cut(pair, csvPairSeparator)
+		if !ok || strings.TrimSpace(category) == "" {
+			return nil, fmt.Errorf("%q is not a Category=Value pair", pair)
+		}
+		stats = append(stats, models.StatisticItem{
+			Category: strings.TrimSpace(category),
+			Value:    parseCSVScalar(strings.TrimSpace(value)),
+		})
+	}
+	return stats, nil
+}
+
+// parseCSVScalar turns numeric cells back into numbers so imported statistics
+// look like the ones Gemini produces.
+func parseCSVScalar(value string) interface{} {
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	return value
+}
+
+// ExportColleges streams the selected colleges to w and returns how many were written
+func ExportColleges(ctx context.Context, w io.Writer, format string, filter ExportFilter) (int, error) {
+	if format != FormatJSONL && format != FormatCSV {
+		return 0, ErrUnsupportedFormat
+	}
+
+	query := bson.M{}
+	if filter.Country != "" {
+		query["country"] = bson.M{"$regex": "^" + regexp.QuoteMeta(filter.Country) + "$", "$options": "i"}
+	}
+	if filter.Name != "" {
+		query["college_name"] = bson.M{"$regex": regexp.QuoteMeta(filter.Name), "$options": "i"}
+	}
+	if !filter.UpdatedSince.IsZero() {
+		query["updated_at"] = bson.M{"$gte": filter.UpdatedSince}
+	}
+	if !filter.IncludeDeleted {
+		query = activeFilter(query)
+	}
+
+	cursor, err := config.CollegeCollection.Find(ctx, query, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var csvWriter *csv.Writer
+	if format == FormatCSV {
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(collegeCSVColumns); err != nil {
+			return 0, err
+		}
+	}
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(interface{ Flush() })
+
+	count := 0
+	for cursor.Next(ctx) {
+		var college models.CollegeStats
+		if err := cursor.Decode(&college); err != nil {
+			log.Printf("❌ Skipping undecodable college during export: %v", err)
+			continue
+		}
+
+		if csvWriter != nil {
+			err = csvWriter.Write(collegeToCSV(college))
+		} else {
+			err = encoder.Encode(college)
+		}
+		if err != nil {
+			return count, err
+		}
+		count++
+
+		if count%100 == 0 {
+			if csvWriter != nil {
+				csvWriter.Flush()
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+
+	if csvWriter != nil {
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return count, err
+		}
+	}
+
+	return count, cursor.Err()
+}
+
+func collegeToCSV(c models.CollegeStats) []string {
+	values := map[string]string{
+		"college_name":                           c.CollegeName,
+		"aliases":                                strings.Join(c.Aliases, csvListSeparator),
+		"country":                                c.Country,
+		"about":                                  c.About,
+		"location":                               c.Location,
+		"summary":                                c.Summary,
+		"ug_programs":                            strings.Join(c.UGPrograms, csvListSeparator),
+		"pg_programs":                            strings.Join(c.PGPrograms, csvListSeparator),
+		"phd_programs":                           strings.Join(c.PhDPrograms, csvListSeparator),
+		"fees.ug_yearly_min":                     strconv.Itoa(c.Fees.UGYearlyMin),
+		"fees.ug_yearly_max":                     strconv.Itoa(c.Fees.UGYearlyMax),
+		"fees.pg_yearly_min":                     strconv.Itoa(c.Fees.PGYearlyMin),
+		"fees.pg_yearly_max":                     strconv.Itoa(c.Fees.PGYearlyMax),
+		"fees.phd_yearly_min":                    strconv.Itoa(c.Fees.PhDYearlyMin),
+		"fees.phd_yearly_max":                    strconv.Itoa(c.Fees.PhDYearlyMax),
+		"scholarships":                           strings.Join(c.Scholarships, csvListSeparator),
+		"student_gender_ratio.male_percentage":   strconv.Itoa(c.StudentGenderRatio.MalePercentage),
+		"student_gender_ratio.female_percentage": strconv.Itoa(c.StudentGenderRatio.FemalePercentage),
+		"faculty_staff":                          strconv.Itoa(c.FacultyStaff),
+		"international_students":                 strconv.Itoa(c.InternationalStudents),
+		"global_ranking":                         c.GlobalRanking,
+		"departments":                            strings.Join(c.Departments, csvListSeparator),
+		"student_statistics":                     formatCSVStatistics(c.StudentStatistics),
+		"additional_details":                     formatCSVStatistics(c.AdditionalDetails),
+		"sources":                                strings.Join(c.Sources, csvListSeparator),
+		"manually_edited":                        strconv.FormatBool(c.ManuallyEdited),
+	}
+	if !c.ID.IsZero() {
+		values["id"] = c.ID.Hex()
+	}
+	if !c.CreatedAt.IsZero() {
+		values["created_at"] = c.CreatedAt.Format(time.RFC3339)
+	}
+	if !c.UpdatedAt.IsZero() {
+		values["updated_at"] = c.UpdatedAt.Format(time.RFC3339)
+	}
+	if c.DeletedAt != nil {
+		values["deleted_at"] = c.DeletedAt.Format(time.RFC3339)
+	}
+
+	record := make([]string, len(collegeCSVColumns))
+	for i, column := range collegeCSVColumns {
+		record[i] = values[column]
+	}
+	return record
+}
+
+func formatCSVStatistics(stats []models.StatisticItem) string {
+	pairs := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		value := ""
+		if stat.Value != nil {
+			value = fmt.Sprint(stat.Value)
+		}
+		pairs = append(pairs, stat.Category+csvPairSeparator+value)
+	}
+	return strings.Join(pairs, csvListSeparator)
+}
@@ -0,0 +1,281 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gobackend/models"
+)
+
+// Row preferences describe which value wins a comparison row
+const (
+	PreferLower    = "lower"
+	PreferHigher   = "higher"
+	PreferBalanced = "balanced"
+	PreferNone     = "none"
+)
+
+var (
+	statisticYear  = regexp.MustCompile(`\b(19|20)\d{2}\b`)
+	emptyBrackets  = regexp.MustCompile(`\(\s*[,;]?\s*\)`)
+	bracketPadding = regexp.MustCompile(`\(\s*[,;]\s*|\s*[,;]\s*\)`)
+)
+
+// ResolveColleges resolves every name concurrently, fetching missing colleges
+// from Gemini. Failures are returned per name.
+func ResolveColleges(names []string) ([]*models.CollegeStats, map[string]string) {
+	colleges := make([]*models.CollegeStats, len(names))
+	failures := make(map[string]string)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			college, _, err := ResolveCollege(name)
+			if err != nil {
+				mu.Lock()
+				failures[name] = err.Error()
+				mu.Unlock()
+				return
+			}
+			colleges[i] = college
+		}(i, name)
+	}
+	wg.Wait()
+
+	return colleges, failures
+}
+
+// CompareColleges builds a comparison matrix of fees, gender ratio, ranking,
+// staffing and every student statistic category shared by at least two of
+// the colleges, marking the best value in each row.
+func CompareColleges(colleges []*models.CollegeStats) *models.Comparison {
+	comparison := &models.Comparison{}
+	for _, college := range colleges {
+		comparison.Colleges = append(comparison.Colleges, college.ToSummary())
+	}
+
+	feeRow := func(key, label string, pick func(models.FeesInfo) (int, bool)) {
+		values := make([]interface{}, len(colleges))
+		for i, college := range colleges {
+			if v, ok := pick(college.Fees); ok {
+				values[i] = v
+			}
+		}
+		comparison.Rows = append(comparison.Rows, newComparisonRow(key, label, "fees", PreferLower, values))
+	}
+	feeRow("fees.ug_yearly_min", "UG yearly fee (min)", func(f models.FeesInfo) (int, bool) { return f.UGYearlyMin, f.UGYearlyMax > 0 })
+	feeRow("fees.ug_yearly_max", "UG yearly fee (max)", func(f models.FeesInfo) (int, bool) { return f.UGYearlyMax, f.UGYearlyMax > 0 })
+	feeRow("fees.pg_yearly_min", "PG yearly fee (min)", func(f models.FeesInfo) (int, bool) { return f.PGYearlyMin, f.PGYearlyMax > 0 })
+	feeRow("fees.pg_yearly_max", "PG yearly fee (max)", func(f models.FeesInfo) (int, bool) { return f.PGYearlyMax, f.PGYearlyMax > 0 })
+	feeRow("fees.phd_yearly_min", "PhD yearly fee (min)", func(f models.FeesInfo) (int, bool) { return f.PhDYearlyMin, f.PhDYearlyMax > 0 })
+	feeRow("fees.phd_yearly_max", "PhD yearly fee (max)", func(f models.FeesInfo) (int, bool) { return f.PhDYearlyMax, f.PhDYearlyMax > 0 })
+
+	female := make([]interface{}, len(colleges))
+	male := make([]interface{}, len(colleges))
+	for i, college := range colleges {
+		if ratio := college.StudentGenderRatio; ratio.MalePercentage+ratio.FemalePercentage > 0 {
+			female[i] = ratio.FemalePercentage
+			male[i] = ratio.MalePercentage
+		}
+	}
+	comparison.Rows = append(comparison.Rows,
+		newComparisonRow("student_gender_ratio.female_percentage", "Female students (%)", "gender_ratio", PreferBalanced, female),
+		newComparisonRow("student_gender_ratio.male_percentage", "Male students (%)", "gender_ratio", PreferBalanced, male),
+	)
+
+	ranking := make([]interface{}, len(colleges))
+	rankValues := make([]interface{}, len(colleges))
+	for i, college := range colleges {
+		if college.GlobalRanking != "" {
+			ranking[i] = college.GlobalRanking
+		}
+		if v := models.ParseRankingValue(college.GlobalRanking); v > 0 {
+			rankValues[i] = v
+		}
+	}
+	rankingRow := newComparisonRow("global_ranking", "Global ranking", "ranking", PreferLower, rankValues)
+	rankingRow.Values = ranking
+	comparison.Rows = append(comparison.Rows, rankingRow)
+
+	faculty := make([]interface{}, len(colleges))
+	international := make([]interface{}, len(colleges))
+	for i, college := range colleges {
+		if college.FacultyStaff > 0 {
+			faculty[i] = college.FacultyStaff
+		}
+		if college.InternationalStudents > 0 {
+			international[i] = college.InternationalStudents
+		}
+	}
+	comparison.Rows = append(comparison.Rows,
+		newComparisonRow("faculty_staff", "Faculty and staff", "staff", PreferHigher, faculty),
+		newComparisonRow("international_students", "International students", "students", PreferHigher, international),
+	)
+
+	comparison.Rows = append(comparison.Rows, statisticRows(colleges, "student_statistics", func(c *models.CollegeStats) []models.StatisticItem { return c.StudentStatistics })...)
+	comparison.Rows = append(comparison.Rows, statisticRows(colleges, "additional_details", func(c *models.CollegeStats) []models.StatisticItem { return c.AdditionalDetails })...)
+
+	return comparison
+}
+
+// statisticRows aligns statistic categories across colleges, ignoring case
+// and the reporting year so "Total students (2024)" lines up with
+// "Total students (2025)". Categories only one college reports are dropped.
+func statisticRows(colleges []*models.CollegeStats, group string, items func(*models.CollegeStats) []models.StatisticItem) []models.ComparisonRow {
+	var order []string
+	labels := make(map[string]string)
+	values := make(map[string][]interface{})
+
+	for i, college := range colleges {
+		for _, stat := range items(college) {
+			key := normalizeCategory(stat.Category)
+			if key == "" {
+				continue
+			}
+			if _, seen := values[key]; !seen {
+				order = append(order, key)
+				labels[key] = stat.Category
+				values[key] = make([]interface{}, len(colleges))
+			}
+			if values[key][i] == nil {
+				values[key][i] = stat.Value
+			}
+		}
+	}
+
+	var rows []models.ComparisonRow
+	for _, key := range order {
+		present := 0
+		for _, v := range values[key] {
+			if v != nil {
+				present++
+			}
+		}
+		if present < 2 {
+			continue
+		}
+
+		preference := PreferHigher
+		if strings.Contains(key, "ratio") || strings.Contains(key, "fee") || strings.Contains(key, "rank") {
+			preference = PreferLower
+		}
+
+		numeric := make([]interface{}, len(colleges))
+		for i, v := range values[key] {
+			if n, ok := numericValue(v); ok {
+				numeric[i] = n
+			} else if v != nil {
+				// Mixed units (e.g. "₹8 LPA" vs "$90k") can't be ranked reliably.
+				preference = PreferNone
+			}
+		}
+
+		row := newComparisonRow(group+"."+key, labels[key], group, preference, numeric)
+		row.Values = values[key]
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// newComparisonRow builds a row and marks the best values according to preference
+func newComparisonRow(key, label, group, preference string, values []interface{}) models.ComparisonRow {
+	row := models.ComparisonRow{Key: key, Label: label, Group: group, Preference: preference, Values: values, Best: []int{}}
+	if preference == PreferNone {
+		return row
+	}
+
+	bestScore := math.Inf(-1)
+	for i, v := range values {
+		n, ok := numericValue(v)
+		if !ok {
+			continue
+		}
+
+		var score float64
+		switch preference {
+		case PreferLower:
+			score = -n
+		case PreferHigher:
+			score = n
+		case PreferBalanced:
+			score = -math.Abs(n - 50)
+		}
+
+		switch {
+		case score > bestScore:
+			bestScore = score
+			row.Best = []int{i}
+		case score == bestScore:
+			row.Best = append(row.Best, i)
+		}
+	}
+
+	// A row where everyone ties has no winner worth highlighting.
+	present := 0
+	for _, v := range values {
+		if v != nil {
+			present++
+		}
+	}
+	if len(row.Best) == present {
+		row.Best = []int{}
+	}
+
+	return row
+}
+
+func normalizeCategory(category string) string {
+	key := strings.ToLower(category)
+	key = statisticYear.ReplaceAllString(key, "")
+	key = emptyBrackets.ReplaceAllString(key, "")
+	key = bracketPadding.ReplaceAllStringFunc(key, func(m string) string {
+		if strings.Contains(m, "(") {
+			return "("
+		}
+		return ")"
+	})
+	return strings.Join(strings.Fields(key), " ")
+}
+
+func numericValue(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(n), ",", ""), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// ParseCompareNames splits and de-duplicates the names parameter of /api/compare
+func ParseCompareNames(raw string, min, max int) ([]string, error) {
+	seen := make(map[string]bool)
+	var names []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		names = append(names, name)
+	}
+
+	if len(names) < min || len(names) > max {
+		return nil, fmt.Errorf("names must list between %d and %d different colleges", min, max)
+	}
+	return names, nil
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"gobackend/models"
+)
+
+// CountriesTopic receives country_added, country_removed and
+// country_count_changed events
+const CountriesTopic = "countries"
+
+type countryCount struct {
+	Name     string
+	Colleges int
+}
+
+// countryDelta is a count after a change; Added marks a country's first college
+type countryDelta struct {
+	countryCount
+	Added bool
+}
+
+// countryIndex counts active colleges per country. It remembers the country
+// of every college so repeated or out-of-order change notifications leave
+// the counts unchanged.
+var countryIndex = struct {
+	sync.RWMutex
+	colleges  map[string]string        // college id -> lowercased country
+	countries map[string]*countryCount // lowercased country -> count
+}{
+	colleges:  make(map[string]string),
+	countries: make(map[string]*countryCount),
+}
+
+// InitializeCountryCounts loads per-country college counts and keeps them up
+// to date, pushing an event to countries subscribers whenever one changes.
+func InitializeCountryCounts() {
+	OnCollegeChange(func(change CollegeChange) {
+		var changed []countryDelta
+		if change.Op == ChangeDelete || change.College == nil {
+			changed = removeCountryCollege(change.ID)
+		} else {
+			changed = putCountryCollege(change.ID, change.College.Country)
+		}
+		for _, delta := range changed {
+			broadcastCountryCount(delta)
+		}
+	})
+
+	count, err := forEachActiveCollege(func(college *models.CollegeStats) {
+		putCountryCollege(college.ID.Hex(), college.Country)
+	})
+	if err != nil {
+		log.Printf("⚠️ Country count load failed: %v", err)
+		return
+	}
+
+	countryIndex.RLock()
+	countries := len(countryIndex.countries)
+	countryIndex.RUnlock()
+	log.Printf("✅ Counted %d colleges across %d countries", count, countries)
+}
+
+// putCountryCollege records that college id is in country and returns the
+// counts that changed as a result.
+func putCountryCollege(id, country string) []countryDelta {
+	key := strings.ToLower(strings.TrimSpace(country))
+	if key == "" {
+		return removeCountryCollege(id)
+	}
+
+	countryIndex.Lock()
+	defer countryIndex.Unlock()
+
+	previous, known := countryIndex.colleges[id]
+	if known && previous == key {
+		return nil
+	}
+
+	var changed []countryDelta
+	if known {
+		changed = append(changed, decrementCountryLocked(previous))
+	}
+
+	count := countryIndex.countries[key]
+	if count == nil {
+		count = &countryCount{Name: strings.TrimSpace(country)}
+		countryIndex.countries[key] = count
+	}
+	count.Colleges++
+	countryIndex.colleges[id] = key
+
+	return append(changed, countryDelta{countryCount: *count, Added: count.Colleges == 1})
+}
+
+// removeCountryCollege forgets college id and returns the count that changed
+func removeCountryCollege(id string) []countryDelta {
+	countryIndex.Lock()
+	defer countryIndex.Unlock()
+
+	previous, known := countryIndex.colleges[id]
+	if !known {
+		return nil
+	}
+	delete(countryIndex.colleges, id)
+	return []countryDelta{decrementCountryLocked(previous)}
+}
+
+func decrementCountryLocked(key string) countryDelta {
+	count := countryIndex.countries[key]
+	count.Colleges--
+	if count.Colleges <= 0 {
+		delete(countryIndex.countries, key)
+	}
+	return countryDelta{countryCount: *count}
+}
+
+// CountryCounts lists countries with at least one active college, by name
+func CountryCounts() []models.CountryCount {
+	countryIndex.RLock()
+	counts := make([]models.CountryCount, 0, len(countryIndex.countries))
+	for _, count := range countryIndex.countries {
+		counts = append(counts, models.CountryCount{ID: count.Name, Name: count.Name, Colleges: count.Colleges})
+	}
+	countryIndex.RUnlock()
+
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Name < counts[j].Name })
+	return counts
+}
+
+// broadcastCountryCount tells countries subscribers about a new, emptied or
+// resized country
+func broadcastCountryCount(delta countryDelta) {
+	eventType := "country_count_changed"
+	switch {
+	case delta.Added:
+		eventType = "country_added"
+	case delta.Colleges == 0:
+		eventType = "country_removed"
+	}
+
+	countryIndex.RLock()
+	total := len(countryIndex.countries)
+	countryIndex.RUnlock()
+
+	publish(BusMessage{
+		Topics: []string{CountriesTopic},
+		Key:    "country_count:" + strings.ToLower(delta.Name),
+		Message: map[string]interface{}{
+			"type":    eventType,
+			"country": models.CountryCount{ID: delta.Name, Name: delta.Name, Colleges: delta.Colleges},
+			"count":   total,
+		},
+	})
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gobackend/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// eventBusMaxMessages and eventBusMaxBytes size the event_bus capped
+	// collection; it only has to hold messages until every instance reads them
+	eventBusMaxMessages = 10000
+	eventBusMaxBytes    = 64 << 20
+)
+
+// busDocument is a BusMessage as stored in the event_bus capped collection
+type busDocument struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	Topics    []string           `bson:"topics"`
+	Key       string             `bson:"key,omitempty"`
+	Message   string             `bson:"message"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+// MongoEventBus shares events between instances through a capped collection
+// that every instance tails
+type MongoEventBus struct {
+	collection *mongo.Collection
+}
+
+// NewMongoEventBus creates the event_bus capped collection if needed
+func NewMongoEventBus() (EventBus, error) {
+	if config.EventBus == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	names, err := config.TruDB.ListCollectionNames(ctx, bson.M{"name": config.EventBus.Name()})
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(eventBusMaxBytes).SetMaxDocuments(eventBusMaxMessages)
+		if err := config.TruDB.CreateCollection(ctx, config.EventBus.Name(), opts); err != nil {
+			return nil, err
+		}
+	}
+
+	return &MongoEventBus{collection: config.EventBus}, nil
+}
+
+// Publish appends msg to the capped collection
+func (b *MongoEventBus) Publish(ctx context.Context, msg BusMessage) error {
+	message, err := json.Marshal(msg.Message)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.collection.InsertOne(ctx, busDocument{
+		ID:        primitive.NewObjectID(),
+		Topics:    msg.Topics,
+		Key:       msg.Key,
+		Message:   string(message),
+		CreatedAt: time.Now().UTC(),
+	})
+	return err
+}
+
+// Subscribe tails the collection from the newest message onwards. Tailable
+// cursors die when the collection is empty or rolls over, so the cursor is
+// reopened until ctx is cancelled. A reopened cursor reads in insertion
+// order from the start and skips up to the last message seen: ObjectIDs from
+// different instances are not ordered within a second, so resuming after
+// the last _id could skip messages another instance inserted meanwhile.
+func (b *MongoEventBus) Subscribe(ctx context.Context, handle func(BusMessage)) error {
+	var last busDocument
+	err := b.collection.FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.M{"$natural": -1})).Decode(&last)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+
+	for ctx.Err() == nil {
+		skipping := false
+		if !last.ID.IsZero() {
+			count, err := b.collection.CountDocuments(ctx, bson.M{"_id": last.ID})
+			if err != nil {
+				return err
+			}
+			skipping = count > 0
+			if !skipping {
+				log.Printf("⚠️ Event bus rolled over past the last message read, some events may be lost")
+			}
+		}
+
+		cursor, err := b.collection.Find(ctx, bson.M{},
+			options.Find().SetCursorType(options.TailableAwait).SetMaxAwaitTime(time.Second))
+		if err != nil {
+			return err
+		}
+
+		for cursor.Next(ctx) {
+			var doc busDocument
+			if err := cursor.Decode(&doc); err != nil {
+				continue
+			}
+			if skipping {
+				// Everything up to last was delivered before the cursor died
+				skipping = doc.ID != last.ID
+				continue
+			}
+			last = doc
+
+			decoder := json.NewDecoder(bytes.NewReader([]byte(doc.Message)))
+			decoder.UseNumber()
+			var message map[string]interface{}
+			if err := decoder.Decode(&message); err != nil {
+				continue
+			}
+			handle(BusMessage{Topics: doc.Topics, Key: doc.Key, Message: message})
+		}
+		err = cursor.Err()
+		cursor.Close(context.Background())
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+	return ctx.Err()
+}
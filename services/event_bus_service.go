@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BusMessage is a broadcast event on its way to every instance's hub. Key
+// is set on events a newer one with the same key fully supersedes (see
+// Event.Key).
+type BusMessage struct {
+	Topics  []string
+	Key     string
+	Message map[string]interface{}
+}
+
+// EventBus fans broadcast events out to every running instance. Each
+// instance subscribes once and delivers what it receives to its own
+// WebSocket and SSE clients, so an implementation must hand every published
+// message to every subscriber, including the publishing instance's.
+type EventBus interface {
+	Publish(ctx context.Context, msg BusMessage) error
+	// Subscribe calls handle for each message until ctx is cancelled
+	Subscribe(ctx context.Context, handle func(BusMessage)) error
+}
+
+// EventBusFactory builds an EventBus; it runs after the database connects
+type EventBusFactory func() (EventBus, error)
+
+var (
+	busFactories   = make(map[string]EventBusFactory)
+	busFactoriesMu sync.RWMutex
+
+	eventBus EventBus
+)
+
+func init() {
+	RegisterEventBus("memory", func() (EventBus, error) { return NewMemoryEventBus(), nil })
+	RegisterEventBus("mongo", NewMongoEventBus)
+}
+
+// RegisterEventBus makes an EventBus implementation selectable by name
+// through EVENT_BUS. Call it from an init function before
+// InitializeEventBus runs.
+func RegisterEventBus(name string, factory EventBusFactory) {
+	busFactoriesMu.Lock()
+	busFactories[name] = factory
+	busFactoriesMu.Unlock()
+}
+
+// InitializeEventBus starts the bus named by EVENT_BUS (default "memory")
+// and delivers everything it carries to this instance's clients. Use "mongo"
+// when several instances run behind a load balancer.
+func InitializeEventBus() {
+	name := os.Getenv("EVENT_BUS")
+	if name == "" {
+		name = "memory"
+	}
+
+	busFactoriesMu.RLock()
+	factory, ok := busFactories[name]
+	busFactoriesMu.RUnlock()
+	if !ok {
+		log.Printf("⚠️ Unknown EVENT_BUS %q (have %s), broadcasting in-process only", name, strings.Join(registeredEventBuses(), ", "))
+		return
+	}
+
+	bus, err := factory()
+	if err != nil {
+		log.Printf("⚠️ Event bus %s unavailable, broadcasting in-process only: %v", name, err)
+		return
+	}
+
+	go func() {
+		backoff := time.Second
+		for {
+			err := bus.Subscribe(context.Background(), func(msg BusMessage) {
+				deliverLocal(msg)
+			})
+			log.Printf("❌ Event bus %s subscription ended: %v (retrying in %s)", name, err, backoff)
+			time.Sleep(backoff)
+			if backoff < 30*time.Second {
+				backoff *= 2
+			}
+		}
+	}()
+
+	eventBus = bus
+	log.Printf("✅ Event bus: %s", name)
+}
+
+func registeredEventBuses() []string {
+	busFactoriesMu.RLock()
+	defer busFactoriesMu.RUnlock()
+
+	names := make([]string, 0, len(busFactories))
+	for name := range busFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// publish sends a broadcast event to clients on every instance. Events that
+// come from the change stream are delivered locally only, because every
+// instance tails the stream itself. If the bus fails, this instance's
+// clients still get the event.
+func publish(msg BusMessage) {
+	if eventBus == nil || ChangeStreamActive() {
+		deliverLocal(msg)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := eventBus.Publish(ctx, msg); err != nil {
+		log.Printf("⚠️ Event bus publish failed, delivering %v locally only: %v", msg.Message["type"], err)
+		deliverLocal(msg)
+	}
+}
+
+// MemoryEventBus delivers messages to subscribers in this process. It is the
+// default for single-instance deployments.
+type MemoryEventBus struct {
+	mu       sync.RWMutex
+	handlers map[int]func(BusMessage)
+	nextID   int
+}
+
+// NewMemoryEventBus creates an empty in-process bus
+func NewMemoryEventBus() *MemoryEventBus {
+	return &MemoryEventBus{handlers: make(map[int]func(BusMessage))}
+}
+
+// Publish runs every handler before returning, preserving publish order
+func (b *MemoryEventBus) Publish(ctx context.Context, msg BusMessage) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if len(b.handlers) == 0 {
+		return fmt.Errorf("no subscribers")
+	}
+	for _, handle := range b.handlers {
+		handle(msg)
+	}
+	return nil
+}
+
+// Subscribe registers handle and blocks until ctx is cancelled
+func (b *MemoryEventBus) Subscribe(ctx context.Context, handle func(BusMessage)) error {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handle
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.handlers, id)
+	b.mu.Unlock()
+	return ctx.Err()
+}
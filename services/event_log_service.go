@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"gobackend/config"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// defaultEventLogSize is how many recent events are kept in memory
+	defaultEventLogSize = 1000
+	// defaultStoredEvents is how many events the capped Mongo log keeps
+	defaultStoredEvents = 100000
+	// eventLogWriteQueue bounds events waiting to be written to Mongo
+	eventLogWriteQueue = 1024
+	// maxReplayEvents is the most a reconnecting client is sent before it is
+	// told to resync instead
+	maxReplayEvents = 10000
+)
+
+// Event is a message queued for subscribers. Broadcast events carry a Seq
+// that increases by one per event; replies to a single client have Seq 0.
+// Key is set when a newer event with the same key makes this one redundant,
+// such as two counts for the same country; the coalesce queue policy uses it.
+type Event struct {
+	Seq     uint64
+	Type    string
+	Topics  []string
+	Key     string
+	Payload []byte
+}
+
+// storedEvent is an Event as kept in the college_events capped collection.
+// Each instance numbers its own events, so they are kept apart by Instance.
+type storedEvent struct {
+	Instance  string    `bson:"instance"`
+	Seq       int64     `bson:"seq"`
+	Type      string    `bson:"type"`
+	Topics    []string  `bson:"topics"`
+	Key       string    `bson:"key,omitempty"`
+	Payload   string    `bson:"payload"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// eventLog holds the most recent broadcast events, oldest first. Sequence
+// numbers start from the boot time in microseconds, so ids handed out by a
+// previous run are always older than anything in this one and get a resync
+// rather than the wrong events.
+var eventLog = struct {
+	sync.Mutex
+	next   uint64
+	size   int
+	events []Event
+	// persist is set when events are also written to Mongo
+	persist chan Event
+}{
+	next: uint64(time.Now().UnixMicro()),
+	size: defaultEventLogSize,
+}
+
+// InitializeEventLog applies EVENT_LOG_SIZE and, when EVENT_LOG_STORE=mongo,
+// keeps events in the college_events capped collection too. A Mongo-backed
+// log continues its sequence across restarts and can replay further back
+// than the in-memory window.
+func InitializeEventLog() {
+	if size, err := strconv.Atoi(os.Getenv("EVENT_LOG_SIZE")); err == nil && size > 0 {
+		eventLog.Lock()
+		eventLog.size = size
+		eventLog.Unlock()
+	}
+
+	if os.Getenv("EVENT_LOG_STORE") != "mongo" {
+		return
+	}
+	if config.EventLog == nil {
+		log.Println("⚠️ EVENT_LOG_STORE=mongo but the database is not connected; keeping events in memory only")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := ensureEventLogCollection(ctx); err != nil {
+		log.Printf("⚠️ Event log collection unavailable, keeping events in memory only: %v", err)
+		return
+	}
+
+	eventLog.Lock()
+	defer eventLog.Unlock()
+
+	cursor, err := config.EventLog.Find(ctx, bson.M{"instance": instanceID()},
+		options.Find().SetSort(bson.D{{Key: "seq", Value: -1}}).SetLimit(int64(eventLog.size)))
+	if err != nil {
+		log.Printf("⚠️ Could not load stored events: %v", err)
+		return
+	}
+	var stored []storedEvent
+	if err := cursor.All(ctx, &stored); err != nil {
+		log.Printf("⚠️ Could not load stored events: %v", err)
+		return
+	}
+
+	events := make([]Event, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		events = append(events, stored[i].event())
+	}
+	eventLog.events = events
+	if len(events) > 0 && events[len(events)-1].Seq >= eventLog.next {
+		eventLog.next = events[len(events)-1].Seq + 1
+	}
+
+	eventLog.persist = make(chan Event, eventLogWriteQueue)
+	go persistEvents(eventLog.persist)
+	log.Printf("✅ Event log loaded %d stored events, next seq %d", len(events), eventLog.next)
+}
+
+func ensureEventLogCollection(ctx context.Context) error {
+	names, err := config.TruDB.ListCollectionNames(ctx, bson.M{"name": config.EventLog.Name()})
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		maxEvents := int64(defaultStoredEvents)
+		if n, err := strconv.ParseInt(os.Getenv("EVENT_LOG_STORED_EVENTS"), 10, 64); err == nil && n > 0 {
+			maxEvents = n
+		}
+		// Sized generously; the document cap is what bounds the log
+		opts := options.CreateCollection().SetCapped(true).SetMaxDocuments(maxEvents).SetSizeInBytes(maxEvents * 4096)
+		if err := config.TruDB.CreateCollection(ctx, config.EventLog.Name(), opts); err != nil {
+			return err
+		}
+	}
+
+	_, err = config.EventLog.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: "instance", Value: 1}, {Key: "seq", Value: 1}}})
+	return err
+}
+
+// persistEvents writes events to Mongo in order, off the publishing path
+func persistEvents(queue <-chan Event) {
+	for event := range queue {
+		doc := storedEvent{
+			Instance:  instanceID(),
+			Seq:       int64(event.Seq),
+			Type:      event.Type,
+			Topics:    event.Topics,
+			Key:       event.Key,
+			Payload:   string(event.Payload),
+			CreatedAt: time.Now().UTC(),
+		}
+		if _, err := config.EventLog.InsertOne(context.Background(), doc); err != nil {
+			log.Printf("❌ Failed to store event %d: %v", event.Seq, err)
+		}
+	}
+}
+
+// instanceID names this instance's events in college_events: INSTANCE_ID if
+// set, otherwise the hostname, which stays the same across restarts in most
+// deployments
+func instanceID() string {
+	if id := os.Getenv("INSTANCE_ID"); id != "" {
+		return id
+	}
+	if host, err := os.Hostname(); err == nil {
+		return host
+	}
+	return "default"
+}
+
+func (s storedEvent) event() Event {
+	return Event{Seq: uint64(s.Seq), Type: s.Type, Topics: s.Topics, Key: s.Key, Payload: []byte(s.Payload)}
+}
+
+// recordEventLocked stamps message with the next sequence number and appends
+// it to the log. The caller holds eventLog.
+func recordEventLocked(msg BusMessage) (Event, error) {
+	seq := eventLog.next
+	msg.Message["seq"] = seq
+
+	payload, err := json.Marshal(msg.Message)
+	if err != nil {
+		return Event{}, err
+	}
+
+	eventType, _ := msg.Message["type"].(string)
+	event := Event{Seq: seq, Type: eventType, Topics: msg.Topics, Key: msg.Key, Payload: payload}
+
+	eventLog.next++
+	eventLog.events = append(eventLog.events, event)
+	if len(eventLog.events) > eventLog.size {
+		eventLog.events = eventLog.events[len(eventLog.events)-eventLog.size:]
+	}
+
+	if eventLog.persist != nil {
+		select {
+		case eventLog.persist <- event:
+		default:
+			log.Printf("⚠️ Event log write queue full, event %d kept in memory only", seq)
+		}
+	}
+	return event, nil
+}
+
+// LatestEventSeq is the sequence number of the newest event, which clients
+// pass back as since (or Last-Event-ID) to resume after a snapshot
+func LatestEventSeq() uint64 {
+	eventLog.Lock()
+	defer eventLog.Unlock()
+	return eventLog.next - 1
+}
+
+// SubscribeSince subscribes client to topics and returns the events on those
+// topics published after seq, oldest first. Later events are queued on the
+// client as usual, so writing the returned events before anything queued
+// delivers every event exactly once and in order. ok is false when the
+// missed events are no longer available and the client must resync. err is
+// set, and nothing is replayed, when client may not follow every topic.
+func SubscribeSince(client *Subscriber, topics map[string]bool, seq uint64) (missed []Event, ok bool, err error) {
+	eventLog.Lock()
+	defer eventLog.Unlock()
+
+	for topic := range topics {
+		if err := Subscribe(client, topic); err != nil {
+			return nil, false, err
+		}
+	}
+
+	events, ok := eventsSinceLocked(seq)
+	for _, event := range events {
+		if event.Matches(topics) {
+			missed = append(missed, event)
+		}
+	}
+	return missed, ok, nil
+}
+
+// eventsSinceLocked returns every event after seq from memory, or from Mongo
+// when they have already left the in-memory window. The caller holds
+// eventLog, which keeps publishes waiting during the Mongo lookup.
+func eventsSinceLocked(seq uint64) ([]Event, bool) {
+	if seq >= eventLog.next {
+		return nil, false
+	}
+
+	oldest := eventLog.next
+	if len(eventLog.events) > 0 {
+		oldest = eventLog.events[0].Seq
+	}
+	if seq+1 >= oldest {
+		var events []Event
+		for _, event := range eventLog.events {
+			if event.Seq > seq {
+				events = append(events, event)
+			}
+		}
+		return events, true
+	}
+
+	if eventLog.persist == nil || oldest-seq > maxReplayEvents {
+		return nil, false
+	}
+	return storedEventsSince(seq, oldest)
+}
+
+// storedEventsSince reads events after seq up to (not including) oldest,
+// the first one still in memory, from Mongo
+func storedEventsSince(seq, oldest uint64) ([]Event, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	cursor, err := config.EventLog.Find(ctx,
+		bson.M{"instance": instanceID(), "seq": bson.M{"$gt": int64(seq), "$lt": int64(oldest)}},
+		options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}))
+	if err != nil {
+		log.Printf("❌ Event replay from Mongo failed: %v", err)
+		return nil, false
+	}
+	var stored []storedEvent
+	if err := cursor.All(ctx, &stored); err != nil {
+		log.Printf("❌ Event replay from Mongo failed: %v", err)
+		return nil, false
+	}
+
+	// A gap means the capped collection has already dropped what we need
+	if len(stored) == 0 || uint64(stored[0].Seq) != seq+1 || uint64(stored[len(stored)-1].Seq) != oldest-1 {
+		return nil, false
+	}
+
+	events := make([]Event, 0, len(stored)+len(eventLog.events))
+	for _, s := range stored {
+		events = append(events, s.event())
+	}
+	return append(events, eventLog.events...), true
+}
+
+// Matches reports whether the event was published to any of topics
+func (e Event) Matches(topics map[string]bool) bool {
+	for _, topic := range e.Topics {
+		if topics[topic] {
+			return true
+		}
+	}
+	return false
+}
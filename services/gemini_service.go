@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"gobackend/models"
 
 	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -166,7 +168,96 @@ Provide realistic data based on actual records.
 func FetchCollegeDataFromGemini(collegeName string) (*models.CollegeStats, error) {
 	startTime := time.Now()
 
-	// Clean and normalize the college name
+	collegeName = normalizeCollegeName(collegeName)
+	log.Printf("🔍 Cleaned college name: %s", collegeName)
+	log.Printf("� Fetching data for: %s", collegeName)
+
+	// Check cache first
+	if cachedData, found := GetFromCache(collegeName); found {
+		log.Printf("📦 Cache HIT for: %s", collegeName)
+		return cachedData, nil
+	}
+
+	// Initialize Gemini client
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	client, model, err := newGeminiModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	prompt := getPrompt(collegeName)
+
+	// Call Gemini API
+	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	if err != nil {
+		return nil, geminiCallError(err)
+	}
+
+	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
+		log.Printf(" Empty response from Gemini")
+		return nil, fmt.Errorf("empty response from Gemini")
+	}
+
+	stats, err := parseGeminiResponse(fmt.Sprint(resp.Candidates[0].Content.Parts[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	elapsedTime := time.Since(startTime)
+	log.Printf("✅ Successfully fetched data for: %s (⏱️ %dms)", stats.CollegeName, elapsedTime.Milliseconds())
+
+	// Save to cache
+	SaveToCache(collegeName, stats)
+
+	return stats, nil
+}
+
+// StreamCollegeTextFromGemini asks Gemini about collegeName (already
+// normalized) with streaming generation, passing each piece of text to
+// onChunk as it arrives, and returns the full response text.
+func StreamCollegeTextFromGemini(ctx context.Context, collegeName string, onChunk func(chunk string)) (string, error) {
+	client, model, err := newGeminiModel(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	var text strings.Builder
+	iter := model.GenerateContentStream(ctx, genai.Text(getPrompt(collegeName)))
+	for {
+		resp, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return "", geminiCallError(err)
+		}
+
+		for _, candidate := range resp.Candidates {
+			if candidate.Content == nil {
+				continue
+			}
+			for _, part := range candidate.Content.Parts {
+				if chunk, ok := part.(genai.Text); ok && chunk != "" {
+					text.WriteString(string(chunk))
+					onChunk(string(chunk))
+				}
+			}
+		}
+	}
+
+	if text.Len() == 0 {
+		log.Printf(" Empty response from Gemini")
+		return "", fmt.Errorf("empty response from Gemini")
+	}
+	return text.String(), nil
+}
+
+// normalizeCollegeName tidies a requested name and drops a trailing country
+func normalizeCollegeName(collegeName string) string {
 	collegeName = strings.TrimSpace(collegeName)
 	collegeName = strings.ToLower(collegeName)
 	collegeName = strings.ReplaceAll(collegeName, "  ", " ")
@@ -184,25 +275,15 @@ func FetchCollegeDataFromGemini(collegeName string) (*models.CollegeStats, error
 		}
 		collegeName = strings.Join(parts, " ")
 	}
-	collegeName = strings.ToTitle(collegeName)
-
-	log.Printf("🔍 Cleaned college name: %s", collegeName)
-	log.Printf("� Fetching data for: %s", collegeName)
-
-	// Check cache first
-	if cachedData, found := GetFromCache(collegeName); found {
-		log.Printf("📦 Cache HIT for: %s", collegeName)
-		return cachedData, nil
-	}
-
-	// Initialize Gemini client
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	return strings.ToTitle(collegeName)
+}
 
+// newGeminiModel opens a Gemini client; the caller closes it
+func newGeminiModel(ctx context.Context) (*genai.Client, *genai.GenerativeModel, error) {
 	apiKey := os.Getenv("GEMINI_API_KEY")
 	if apiKey == "" {
 		log.Printf("❌ GEMINI_API_KEY not set in environment")
-		return nil, fmt.Errorf("GEMINI_API_KEY not set in .env file")
+		return nil, nil, fmt.Errorf("GEMINI_API_KEY not set in .env file")
 	}
 
 	client, err := genai.NewClient(ctx, option.WithAPIKey(apiKey))
@@ -211,42 +292,35 @@ func FetchCollegeDataFromGemini(collegeName string) (*models.CollegeStats, error
 		if strings.Contains(err.Error(), "403") {
 			log.Printf("🔴 API Key Error: Your Gemini API key may be compromised or invalid")
 		}
-		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
+		return nil, nil, fmt.Errorf("failed to create Gemini client: %w", err)
 	}
-	defer client.Close()
 
-	model := client.GenerativeModel("gemini-2.0-flash")
-
-	prompt := getPrompt(collegeName)
-
-	// Call Gemini API
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
-	if err != nil {
-		log.Printf("❌ Gemini API error: %v", err)
+	return client, client.GenerativeModel("gemini-2.0-flash"), nil
+}
 
-		// Better error messages for common issues
-		if strings.Contains(err.Error(), "403") || strings.Contains(err.Error(), "leaked") {
-			log.Printf("🔴 CRITICAL: Your API key has been reported as leaked or is invalid!")
-			log.Printf("📌 Action required: Get a new API key from https://aistudio.google.com")
-			return nil, fmt.Errorf("API key compromised. Get a new one from https://aistudio.google.com")
-		}
-		if strings.Contains(err.Error(), "429") {
-			return nil, fmt.Errorf("API rate limit exceeded. Please try again later")
-		}
-		if strings.Contains(err.Error(), "401") {
-			return nil, fmt.Errorf("API authentication failed. Check your GEMINI_API_KEY")
-		}
+// geminiCallError turns a failed Gemini call into a readable error
+func geminiCallError(err error) error {
+	log.Printf("❌ Gemini API error: %v", err)
 
-		return nil, fmt.Errorf("failed to call Gemini API: %w", err)
+	// Better error messages for common issues
+	if strings.Contains(err.Error(), "403") || strings.Contains(err.Error(), "leaked") {
+		log.Printf("🔴 CRITICAL: Your API key has been reported as leaked or is invalid!")
+		log.Printf("📌 Action required: Get a new API key from https://aistudio.google.com")
+		return fmt.Errorf("API key compromised. Get a new one from https://aistudio.google.com")
 	}
-
-	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
-		log.Printf(" Empty response from Gemini")
-		return nil, fmt.Errorf("empty response from Gemini")
+	if strings.Contains(err.Error(), "429") {
+		return fmt.Errorf("API rate limit exceeded. Please try again later")
+	}
+	if strings.Contains(err.Error(), "401") {
+		return fmt.Errorf("API authentication failed. Check your GEMINI_API_KEY")
 	}
 
-	text := fmt.Sprint(resp.Candidates[0].Content.Parts[0])
+	return fmt.Errorf("failed to call Gemini API: %w", err)
+}
 
+// parseGeminiResponse reads the JSON Gemini returned, with or without a
+// markdown code fence around it
+func parseGeminiResponse(text string) (*models.CollegeStats, error) {
 	// Remove markdown code blocks if present
 	text = strings.TrimSpace(text)
 	if strings.HasPrefix(text, "```") {
@@ -267,15 +341,7 @@ func FetchCollegeDataFromGemini(collegeName string) (*models.CollegeStats, error
 	}
 
 	// Convert to CollegeStats model
-	stats := mapGeminiResponseToCollegeStats(data)
-
-	elapsedTime := time.Since(startTime)
-	log.Printf("✅ Successfully fetched data for: %s (⏱️ %dms)", stats.CollegeName, elapsedTime.Milliseconds())
-
-	// Save to cache
-	SaveToCache(collegeName, stats)
-
-	return stats, nil
+	return mapGeminiResponseToCollegeStats(data), nil
 }
 
 // mapGeminiResponseToCollegeStats converts Gemini JSON response to CollegeStats model
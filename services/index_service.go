@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gobackend/config"
+	"gobackend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// collegeIndexes are created on startup. CreateMany is a no-op for indexes
+// that already exist with the same definition.
+var collegeIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{
+			{Key: "college_name", Value: "text"},
+			{Key: "location", Value: "text"},
+			{Key: "about", Value: "text"},
+			{Key: "departments", Value: "text"},
+			{Key: "ug_programs", Value: "text"},
+			{Key: "pg_programs", Value: "text"},
+			{Key: "phd_programs", Value: "text"},
+		},
+		Options: options.Index().
+			SetName("college_text_search").
+			SetWeights(bson.D{
+				{Key: "college_name", Value: 10},
+				{Key: "location", Value: 4},
+				{Key: "departments", Value: 3},
+				{Key: "ug_programs", Value: 2},
+				{Key: "pg_programs", Value: 2},
+				{Key: "phd_programs", Value: 2},
+				{Key: "about", Value: 1},
+			}),
+	},
+	// Keyset pagination sorts on (field, _id)
+	{Keys: bson.D{{Key: "college_name", Value: 1}, {Key: "_id", Value: 1}}},
+	{Keys: bson.D{{Key: "country", Value: 1}, {Key: "_id", Value: 1}}},
+	{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+	{Keys: bson.D{{Key: "updated_at", Value: 1}, {Key: "_id", Value: 1}}},
+	// Attribute filters on /api/colleges
+	{Keys: bson.D{{Key: "fees.ug_yearly_min", Value: 1}, {Key: "_id", Value: 1}}},
+	{Keys: bson.D{{Key: "fees.pg_yearly_min", Value: 1}, {Key: "_id", Value: 1}}},
+	{Keys: bson.D{{Key: "fees.phd_yearly_min", Value: 1}, {Key: "_id", Value: 1}}},
+	{Keys: bson.D{{Key: "fees.ug_yearly_max", Value: 1}}},
+	{Keys: bson.D{{Key: "fees.pg_yearly_max", Value: 1}}},
+	{Keys: bson.D{{Key: "fees.phd_yearly_max", Value: 1}}},
+	{Keys: bson.D{{Key: "global_ranking_value", Value: 1}, {Key: "_id", Value: 1}}},
+	{Keys: bson.D{{Key: "faculty_staff", Value: 1}, {Key: "_id", Value: 1}}},
+	{Keys: bson.D{{Key: "international_students", Value: 1}, {Key: "_id", Value: 1}}},
+	{Keys: bson.D{{Key: "student_gender_ratio.female_percentage", Value: 1}}},
+	{Keys: bson.D{{Key: "program_keys", Value: 1}}},
+	{Keys: bson.D{{Key: "department_keys", Value: 1}}},
+}
+
+// EnsureIndexes creates the indexes the query endpoints rely on
+func EnsureIndexes() {
+	if config.CollegeCollection == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	names, err := config.CollegeCollection.Indexes().CreateMany(ctx, collegeIndexes)
+	if err != nil {
+		log.Printf("⚠️ Failed to create indexes: %v", err)
+		return
+	}
+	log.Printf("✅ Indexes ready: %v", names)
+}
+
+// BackfillRankingValues derives global_ranking_value for records written
+// before the field existed.
+func BackfillRankingValues() {
+	if config.CollegeCollection == nil {
+		return
+	}
+
+	ctx := context.Background()
+	cursor, err := config.CollegeCollection.Find(ctx,
+		bson.M{"global_ranking_value": bson.M{"$exists": false}, "global_ranking": bson.M{"$nin": bson.A{"", nil}}},
+		options.Find().SetProjection(bson.M{"global_ranking": 1}))
+	if err != nil {
+		log.Printf("⚠️ Ranking backfill failed: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	updated := 0
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID            interface{} `bson:"_id"`
+			GlobalRanking string      `bson:"global_ranking"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			continue
+		}
+		value := models.ParseRankingValue(doc.GlobalRanking)
+		if value == 0 {
+			continue
+		}
+		if _, err := config.CollegeCollection.UpdateByID(ctx, doc.ID, bson.M{"$set": bson.M{"global_ranking_value": value}}); err == nil {
+			updated++
+		}
+	}
+
+	if updated > 0 {
+		log.Printf("✅ Backfilled global_ranking_value on %d colleges", updated)
+	}
+}
+
+// BackfillFilterKeys derives program_keys and department_keys for records
+// written before the program and department filters used them.
+func BackfillFilterKeys() {
+	if config.CollegeCollection == nil {
+		return
+	}
+
+	ctx := context.Background()
+	cursor, err := config.CollegeCollection.Find(ctx,
+		bson.M{"program_keys": bson.M{"$exists": false}},
+		options.Find().SetProjection(bson.M{"ug_programs": 1, "pg_programs": 1, "phd_programs": 1, "departments": 1}))
+	if err != nil {
+		log.Printf("⚠️ Filter key backfill failed: %v", err)
+		return
+	}
+	defer cursor.Close(ctx)
+
+	updated := 0
+	for cursor.Next(ctx) {
+		var doc models.CollegeStats
+		if err := cursor.Decode(&doc); err != nil {
+			continue
+		}
+		doc.SetDerivedFields()
+		if _, err := config.CollegeCollection.UpdateByID(ctx, doc.ID, bson.M{"$set": bson.M{
+			"program_keys":    doc.ProgramKeys,
+			"department_keys": doc.DepartmentKeys,
+		}}); err == nil {
+			updated++
+		}
+	}
+
+	if updated > 0 {
+		log.Printf("✅ Backfilled program and department filter keys on %d colleges", updated)
+	}
+}
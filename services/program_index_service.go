@@ -0,0 +1,368 @@
+package services
+
+import (
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"gobackend/models"
+)
+
+// degreeAliases maps a degree written without dots or spaces to its
+// canonical spelling and level.
+var degreeAliases = map[string]struct{ Name, Level string }{
+	"btech": {"B.Tech", models.LevelUG}, "be": {"B.E.", models.LevelUG}, "bsc": {"B.Sc", models.LevelUG},
+	"ba": {"B.A", models.LevelUG}, "bcom": {"B.Com", models.LevelUG}, "bba": {"BBA", models.LevelUG},
+	"barch": {"B.Arch", models.LevelUG}, "bdes": {"B.Des", models.LevelUG}, "bpharm": {"B.Pharm", models.LevelUG},
+	"bca": {"BCA", models.LevelUG}, "llb": {"LLB", models.LevelUG}, "mbbs": {"MBBS", models.LevelUG},
+	"bds": {"BDS", models.LevelUG}, "beng": {"B.Eng", models.LevelUG}, "bs": {"B.S", models.LevelUG},
+	"mtech": {"M.Tech", models.LevelPG}, "me": {"M.E.", models.LevelPG}, "msc": {"M.Sc", models.LevelPG},
+	"ma": {"M.A", models.LevelPG}, "mcom": {"M.Com", models.LevelPG}, "mba": {"MBA", models.LevelPG},
+	"mca": {"MCA", models.LevelPG}, "march": {"M.Arch", models.LevelPG}, "mdes": {"M.Des", models.LevelPG},
+	"mpharm": {"M.Pharm", models.LevelPG}, "llm": {"LLM", models.LevelPG}, "ms": {"M.S", models.LevelPG},
+	"meng": {"M.Eng", models.LevelPG}, "mres": {"MRes", models.LevelPG}, "mphil": {"M.Phil", models.LevelPG},
+	"md": {"MD", models.LevelPG}, "pgdm": {"PGDM", models.LevelPG},
+	"phd": {"PhD", models.LevelPhD}, "dphil": {"DPhil", models.LevelPhD}, "edd": {"EdD", models.LevelPhD},
+}
+
+// degreeLongForms are spelled-out degree names, checked before abbreviations
+var degreeLongForms = []struct{ Prefix, Alias string }{
+	{"bachelor of technology", "btech"}, {"bachelor of engineering", "be"}, {"bachelor of science", "bsc"},
+	{"bachelor of arts", "ba"}, {"bachelor of commerce", "bcom"}, {"bachelor of business administration", "bba"},
+	{"bachelor of architecture", "barch"}, {"bachelor of design", "bdes"}, {"bachelor of laws", "llb"},
+	{"master of technology", "mtech"}, {"master of engineering", "meng"}, {"master of science", "msc"},
+	{"master of arts", "ma"}, {"master of commerce", "mcom"}, {"master of business administration", "mba"},
+	{"master of computer applications", "mca"}, {"master of architecture", "march"}, {"master of laws", "llm"},
+	{"master of philosophy", "mphil"}, {"doctor of philosophy", "phd"},
+}
+
+var (
+	disciplineNoise = regexp.MustCompile(`(?i)^(\(?hons\.?\)?|honours|in|of|-|:|–)\s+`)
+	nonWord         = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+)
+
+// ProgramIndex maps normalized programs to the colleges offering them
+type ProgramIndex struct {
+	mu       sync.RWMutex
+	programs map[string]*programEntry
+	colleges map[string]*indexedCollege
+}
+
+type programEntry struct {
+	models.Program
+	disciplineKey string
+	colleges      map[string]bool
+}
+
+type indexedCollege struct {
+	summary  models.CollegeSummary
+	programs []string
+}
+
+var programIndex = &ProgramIndex{
+	programs: make(map[string]*programEntry),
+	colleges: make(map[string]*indexedCollege),
+}
+
+// InitializeProgramIndex loads every active college into the program index
+// and keeps it in sync with later writes.
+func InitializeProgramIndex() {
+	OnCollegeChange(func(change CollegeChange) {
+		if change.Op == ChangeDelete || change.College == nil {
+			programIndex.remove(change.ID)
+			return
+		}
+		programIndex.put(change.College)
+	})
+
+	count, err := forEachActiveCollege(programIndex.put)
+	if err != nil {
+		log.Printf("⚠️ Program index load failed: %v", err)
+		return
+	}
+	log.Printf("✅ Program index built from %d colleges (%d programs)", count, programIndex.size())
+}
+
+// NormalizeProgram splits a free-form program name into degree, discipline and
+// level. level is the list the program came from and is used when the degree
+// is not recognised.
+func NormalizeProgram(raw, level string) models.Program {
+	text := strings.Join(strings.Fields(raw), " ")
+
+	program := models.Program{Level: level}
+	rest := text
+
+	matched := false
+	for _, form := range degreeLongForms {
+		// Compare bytes of text itself: lowercasing can change their length
+		if len(text) >= len(form.Prefix) && strings.EqualFold(text[:len(form.Prefix)], form.Prefix) {
+			alias := degreeAliases[form.Alias]
+			program.Degree, rest, matched = alias.Name, text[len(form.Prefix):], true
+			if level == "" {
+				program.Level = alias.Level
+			}
+			break
+		}
+	}
+
+	if !matched {
+		first, remainder, _ := strings.Cut(text, " ")
+		key := strings.ToLower(strings.NewReplacer(".", "", "(", "", ")", "", ",", "").Replace(first))
+		if alias, ok := degreeAliases[key]; ok {
+			program.Degree, rest = alias.Name, remainder
+			if level == "" {
+				program.Level = alias.Level
+			}
+		}
+	}
+
+	rest = strings.TrimSpace(rest)
+	for {
+		loc := disciplineNoise.FindStringIndex(rest)
+		if loc == nil {
+			break
+		}
+		rest = strings.TrimSpace(rest[loc[1]:])
+	}
+	program.Discipline = strings.Trim(rest, " -:,()")
+
+	program.Name = strings.TrimSpace(program.Degree + " " + program.Discipline)
+	if program.Name == "" {
+		program.Name = text
+	}
+	return program
+}
+
+func disciplineKey(discipline string) string {
+	key := strings.ToLower(strings.ReplaceAll(discipline, "&", " and "))
+	return strings.TrimSpace(nonWord.ReplaceAllString(key, " "))
+}
+
+func programKey(p models.Program) string {
+	return p.Level + "|" + strings.ToLower(p.Degree) + "|" + disciplineKey(p.Discipline)
+}
+
+func (idx *ProgramIndex) put(college *models.CollegeStats) {
+	id := college.ID.Hex()
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.removeLocked(id)
+
+	entry := &indexedCollege{summary: college.ToSummary()}
+	for level, programs := range collegeProgramsByLevel(college) {
+		for _, raw := range programs {
+			program := NormalizeProgram(raw, level)
+			key := programKey(program)
+
+			existing, ok := idx.programs[key]
+			if !ok {
+				existing = &programEntry{Program: program, disciplineKey: disciplineKey(program.Discipline), colleges: make(map[string]bool)}
+				idx.programs[key] = existing
+			}
+			if !existing.colleges[id] {
+				existing.colleges[id] = true
+				entry.programs = append(entry.programs, key)
+			}
+		}
+	}
+	idx.colleges[id] = entry
+}
+
+func (idx *ProgramIndex) remove(id string) {
+	idx.mu.Lock()
+	idx.removeLocked(id)
+	idx.mu.Unlock()
+}
+
+func (idx *ProgramIndex) removeLocked(id string) {
+	college, ok := idx.colleges[id]
+	if !ok {
+		return
+	}
+	for _, key := range college.programs {
+		if entry, ok := idx.programs[key]; ok {
+			delete(entry.colleges, id)
+			if len(entry.colleges) == 0 {
+				delete(idx.programs, key)
+			}
+		}
+	}
+	delete(idx.colleges, id)
+}
+
+func (idx *ProgramIndex) size() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.programs)
+}
+
+// ProgramQuery selects programs from the index. Empty fields match everything.
+type ProgramQuery struct {
+	Program string
+	Level   string
+	Country string
+	MaxFee  int
+	Limit   int
+}
+
+// programMatcher tests normalized programs against a program query
+type programMatcher struct {
+	level      string
+	degree     string
+	discipline string
+}
+
+// newProgramMatcher parses a free-form program query. When level is empty it
+// is taken from the query's degree, if recognised.
+func newProgramMatcher(program, level string) programMatcher {
+	m := programMatcher{level: level}
+	if strings.TrimSpace(program) == "" {
+		return m
+	}
+
+	wanted := NormalizeProgram(program, "")
+	if wanted.Degree == "" {
+		// No recognised degree: treat the whole query as a discipline.
+		m.discipline = disciplineKey(program)
+		return m
+	}
+
+	m.degree, m.discipline = wanted.Degree, disciplineKey(wanted.Discipline)
+	if m.level == "" {
+		m.level = wanted.Level
+	}
+	return m
+}
+
+// match reports whether program, whose discipline normalizes to key, is
+// what the query asked for. Disciplines match anywhere in the key.
+func (m programMatcher) match(program models.Program, key string) bool {
+	if m.level != "" && program.Level != m.level {
+		return false
+	}
+	if m.degree != "" && !strings.EqualFold(program.Degree, m.degree) {
+		return false
+	}
+	return m.discipline == "" || strings.Contains(key, m.discipline)
+}
+
+// MatchCollegePrograms returns the programs of college matching a program
+// query, at the given level or any level when empty.
+func MatchCollegePrograms(college *models.CollegeStats, program, level string) []models.Program {
+	m := newProgramMatcher(program, level)
+
+	var matched []models.Program
+	for programLevel, programs := range collegeProgramsByLevel(college) {
+		for _, raw := range programs {
+			p := NormalizeProgram(raw, programLevel)
+			if m.match(p, disciplineKey(p.Discipline)) {
+				matched = append(matched, p)
+			}
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched
+}
+
+func collegeProgramsByLevel(college *models.CollegeStats) map[string][]string {
+	return map[string][]string{
+		models.LevelUG:  college.UGPrograms,
+		models.LevelPG:  college.PGPrograms,
+		models.LevelPhD: college.PhDPrograms,
+	}
+}
+
+// ListPrograms returns known programs with the number of colleges offering
+// each, most common first.
+func ListPrograms(q ProgramQuery) []models.ProgramCount {
+	m := newProgramMatcher(q.Program, q.Level)
+
+	programIndex.mu.RLock()
+	results := make([]models.ProgramCount, 0)
+	for _, entry := range programIndex.programs {
+		if m.match(entry.Program, entry.disciplineKey) {
+			results = append(results, models.ProgramCount{Program: entry.Program, Colleges: len(entry.colleges)})
+		}
+	}
+	programIndex.mu.RUnlock()
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Colleges != results[j].Colleges {
+			return results[i].Colleges > results[j].Colleges
+		}
+		return results[i].Name < results[j].Name
+	})
+
+	if q.Limit > 0 && len(results) > q.Limit {
+		results = results[:q.Limit]
+	}
+	return results
+}
+
+// SearchCollegesByProgram returns colleges offering a program matching the
+// query, optionally limited to a country and to yearly fees at or below
+// MaxFee for the matched program's level.
+func SearchCollegesByProgram(q ProgramQuery) []models.ProgramMatch {
+	m := newProgramMatcher(q.Program, q.Level)
+
+	programIndex.mu.RLock()
+	matches := make(map[string]*models.ProgramMatch)
+	for _, entry := range programIndex.programs {
+		if !m.match(entry.Program, entry.disciplineKey) {
+			continue
+		}
+		for id := range entry.colleges {
+			college := programIndex.colleges[id]
+			if q.Country != "" && !strings.EqualFold(college.summary.Country, q.Country) {
+				continue
+			}
+			if q.MaxFee > 0 {
+				if fee, ok := entryFee(college.summary.Fees, entry.Level); !ok || fee > q.MaxFee {
+					continue
+				}
+			}
+
+			match, ok := matches[id]
+			if !ok {
+				match = &models.ProgramMatch{College: college.summary}
+				matches[id] = match
+			}
+			match.MatchedPrograms = append(match.MatchedPrograms, entry.Program)
+		}
+	}
+	programIndex.mu.RUnlock()
+
+	results := make([]models.ProgramMatch, 0, len(matches))
+	for _, match := range matches {
+		sort.Slice(match.MatchedPrograms, func(i, j int) bool { return match.MatchedPrograms[i].Name < match.MatchedPrograms[j].Name })
+		results = append(results, *match)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if len(results[i].MatchedPrograms) != len(results[j].MatchedPrograms) {
+			return len(results[i].MatchedPrograms) > len(results[j].MatchedPrograms)
+		}
+		return results[i].College.CollegeName < results[j].College.CollegeName
+	})
+
+	if q.Limit > 0 && len(results) > q.Limit {
+		results = results[:q.Limit]
+	}
+	return results
+}
+
+// entryFee is the starting yearly fee for a level, false when not reported
+func entryFee(fees models.FeesInfo, level string) (int, bool) {
+	switch level {
+	case models.LevelUG:
+		return fees.UGYearlyMin, fees.UGYearlyMax > 0
+	case models.LevelPG:
+		return fees.PGYearlyMin, fees.PGYearlyMax > 0
+	case models.LevelPhD:
+		return fees.PhDYearlyMin, fees.PhDYearlyMax > 0
+	}
+	return 0, false
+}
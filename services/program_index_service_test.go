@@ -0,0 +1,73 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"gobackend/models"
+)
+
+func TestNormalizeProgram(t *testing.T) {
+	tests := []struct {
+		raw, level string
+		want       models.Program
+	}{
+		{"B.Tech Computer Science", "", models.Program{Name: "B.Tech Computer Science", Degree: "B.Tech", Discipline: "Computer Science", Level: models.LevelUG}},
+		{"Bachelor of Technology in Civil Engineering", "", models.Program{Name: "B.Tech Civil Engineering", Degree: "B.Tech", Discipline: "Civil Engineering", Level: models.LevelUG}},
+		{"BACHELOR OF SCIENCE (Hons) Physics", "", models.Program{Name: "B.Sc Physics", Degree: "B.Sc", Discipline: "Physics", Level: models.LevelUG}},
+		{"MSc  in   Data Science", "", models.Program{Name: "M.Sc Data Science", Degree: "M.Sc", Discipline: "Data Science", Level: models.LevelPG}},
+		{"MBA", models.LevelPG, models.Program{Name: "MBA", Degree: "MBA", Level: models.LevelPG}},
+		{"Economics", models.LevelUG, models.Program{Name: "Economics", Discipline: "Economics", Level: models.LevelUG}},
+		{"PhD - Chemistry", "", models.Program{Name: "PhD Chemistry", Degree: "PhD", Discipline: "Chemistry", Level: models.LevelPhD}},
+
+		// Lowercasing changes the byte length of these runes
+		{"B.Tech Ⱥstronomy", "", models.Program{Name: "B.Tech Ⱥstronomy", Degree: "B.Tech", Discipline: "Ⱥstronomy", Level: models.LevelUG}},
+		{"M.Sc in ẞtudies", "", models.Program{Name: "M.Sc ẞtudies", Degree: "M.Sc", Discipline: "ẞtudies", Level: models.LevelPG}},
+		{"B.A İn Ⱥrt", "", models.Program{Name: "B.A İn Ⱥrt", Degree: "B.A", Discipline: "İn Ⱥrt", Level: models.LevelUG}},
+		{"Bachelor of Arts Ⱥ", "", models.Program{Name: "B.A Ⱥ", Degree: "B.A", Discipline: "Ⱥ", Level: models.LevelUG}},
+		{"Ⱥ Bachelor of Arts", "", models.Program{Name: "Ⱥ Bachelor of Arts", Discipline: "Ⱥ Bachelor of Arts"}},
+		{"Licenciatura en Matemáticas", models.LevelUG, models.Program{Name: "Licenciatura en Matemáticas", Discipline: "Licenciatura en Matemáticas", Level: models.LevelUG}},
+		{"工学部 情報工学", models.LevelUG, models.Program{Name: "工学部 情報工学", Discipline: "工学部 情報工学", Level: models.LevelUG}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := NormalizeProgram(tt.raw, tt.level); got != tt.want {
+				t.Errorf("NormalizeProgram(%q, %q) = %+v, want %+v", tt.raw, tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchCollegePrograms(t *testing.T) {
+	college := &models.CollegeStats{
+		UGPrograms:  []string{"B.Tech Computer Science", "B.Sc Physics"},
+		PGPrograms:  []string{"M.Tech Computer Science and Engineering", "MBA"},
+		PhDPrograms: []string{"PhD Computer Science"},
+	}
+
+	tests := []struct {
+		program, level string
+		want           []string
+	}{
+		{"computer science", "", []string{"B.Tech Computer Science", "M.Tech Computer Science and Engineering", "PhD Computer Science"}},
+		{"Computer Science", models.LevelPG, []string{"M.Tech Computer Science and Engineering"}},
+		{"science and eng", "", []string{"M.Tech Computer Science and Engineering"}},
+		{"Bachelor of Technology in Computer", "", []string{"B.Tech Computer Science"}},
+		{"MBA", "", []string{"MBA"}},
+		{"", models.LevelUG, []string{"B.Sc Physics", "B.Tech Computer Science"}},
+		{"law", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.program+"/"+tt.level, func(t *testing.T) {
+			var got []string
+			for _, p := range MatchCollegePrograms(college, tt.program, tt.level) {
+				got = append(got, p.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatchCollegePrograms(%q, %q) = %q, want %q", tt.program, tt.level, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gobackend/config"
+	"gobackend/models"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// rankingFloor is the global rank that scores zero on the ranking component
+const rankingFloor = 2000
+
+var (
+	percentSign = regexp.MustCompile(`\s*%\s*$`)
+	// rateCategory matches statistic categories whose values are rates
+	rateCategory = regexp.MustCompile(`\b(rate|ratio|percent|percentage)\b|%`)
+	levelLabels  = map[string]string{models.LevelUG: "UG", models.LevelPG: "PG", models.LevelPhD: "PhD"}
+)
+
+// RecommendColleges scores every stored college against the request and
+// returns the best matches. Colleges outside the requested countries, without
+// a matching program, or whose fees exceed the budget are left out.
+func RecommendColleges(req models.RecommendationRequest) (*models.RecommendationResponse, error) {
+	level := req.Level
+	if level == "" {
+		if p := NormalizeProgram(req.Program, ""); p.Degree != "" {
+			level = p.Level
+		} else {
+			level = models.LevelUG
+		}
+	}
+
+	colleges, err := recommendationCandidates(req.Countries)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.RecommendationResponse{Criteria: req, Considered: len(colleges)}
+	response.Criteria.Level = level
+
+	// Without a budget, fees are scored relative to the cheapest and most
+	// expensive candidates.
+	lowest, highest := math.MaxInt, 0
+	for _, college := range colleges {
+		if fee, ok := entryFee(college.Fees, level); ok {
+			lowest, highest = min(lowest, fee), max(highest, fee)
+		}
+	}
+
+	results := make([]models.Recommendation, 0, len(colleges))
+	for i := range colleges {
+		college := &colleges[i]
+
+		var matched []models.Program
+		if strings.TrimSpace(req.Program) != "" {
+			if matched = MatchCollegePrograms(college, req.Program, level); len(matched) == 0 {
+				response.ExcludedProgram++
+				continue
+			}
+		}
+
+		fees, fits := feeComponent(college, level, req.Budget, lowest, highest)
+		if !fits {
+			response.ExcludedBudget++
+			continue
+		}
+
+		components := []models.ScoreComponent{rankingComponent(college), placementComponent(college), fees}
+		results = append(results, models.Recommendation{
+			College:         college.ToSummary(),
+			Score:           weighScore(components, req.Weights),
+			Components:      components,
+			MatchedPrograms: matched,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].College.CollegeName < results[j].College.CollegeName
+	})
+	if len(results) > req.Limit {
+		results = results[:req.Limit]
+	}
+	response.Results = results
+	return response, nil
+}
+
+// recommendationCandidates loads active colleges, limited to the given
+// countries when there are any.
+func recommendationCandidates(countries []string) ([]models.CollegeStats, error) {
+	filter := bson.M{}
+	if len(countries) > 0 {
+		patterns := bson.A{}
+		for _, country := range countries {
+			patterns = append(patterns, primitive.Regex{Pattern: "^" + regexp.QuoteMeta(strings.TrimSpace(country)) + "$", Options: "i"})
+		}
+		filter["country"] = bson.M{"$in": patterns}
+	}
+
+	cursor, err := config.CollegeCollection.Find(context.TODO(), activeFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+
+	var colleges []models.CollegeStats
+	if err := cursor.All(context.TODO(), &colleges); err != nil {
+		return nil, err
+	}
+	return colleges, nil
+}
+
+// weighScore fills in each component's weight and contribution and returns
+// the total on a 0-100 scale. Weights of unavailable components are spread
+// over the available ones.
+func weighScore(components []models.ScoreComponent, weights *models.RecommendationWeights) float64 {
+	raw := map[string]float64{"ranking": weights.Ranking, "placement": weights.Placement, "fees": weights.Fees}
+
+	available := 0.0
+	for _, c := range components {
+		if c.Available {
+			available += raw[c.Name]
+		}
+	}
+
+	total := 0.0
+	for i := range components {
+		c := &components[i]
+		if !c.Available || available == 0 {
+			continue
+		}
+		c.Weight = round3(raw[c.Name] / available)
+		c.Contribution = round3(c.Weight * c.Score)
+		total += raw[c.Name] / available * c.Score
+	}
+	return math.Round(total*1000) / 10
+}
+
+func rankingComponent(college *models.CollegeStats) models.ScoreComponent {
+	c := models.ScoreComponent{Name: "ranking"}
+	rank := college.GlobalRankingValue
+	if rank <= 0 {
+		c.Explanation = "no global ranking reported"
+		return c
+	}
+
+	c.Available = true
+	c.Value = rank
+	c.Score = round3(math.Max(0, 1-math.Log10(float64(rank))/math.Log10(rankingFloor)))
+	c.Explanation = fmt.Sprintf("ranked #%d globally (#1 scores 1, #%d or lower scores 0)", rank, rankingFloor)
+	return c
+}
+
+func placementComponent(college *models.CollegeStats) models.ScoreComponent {
+	c := models.ScoreComponent{Name: "placement"}
+	rate, category, ok := placementRate(college)
+	if !ok {
+		c.Explanation = "no placement rate reported"
+		return c
+	}
+
+	c.Available = true
+	c.Value = rate
+	c.Score = round3(rate / 100)
+	c.Explanation = fmt.Sprintf("%.0f%% placement rate (%s)", rate, category)
+	return c
+}
+
+// placementRate finds a placement rate among the college's statistics, as a
+// percentage. Only values given with a % sign, or in a category that names a
+// rate or percentage, count: "median placement salary: 45" is not a rate.
+// Unit-less values below 1 in a rate category are read as fractions.
+func placementRate(college *models.CollegeStats) (float64, string, bool) {
+	for _, stats := range [][]models.StatisticItem{college.StudentStatistics, college.AdditionalDetails} {
+		for _, stat := range stats {
+			category := strings.ToLower(stat.Category)
+			if !strings.Contains(category, "placement") && !strings.Contains(category, "employment") {
+				continue
+			}
+
+			value, percent := stat.Value, false
+			if s, ok := value.(string); ok && percentSign.MatchString(s) {
+				value, percent = percentSign.ReplaceAllString(s, ""), true
+			}
+			if !percent && !rateCategory.MatchString(category) {
+				continue
+			}
+
+			rate, ok := numericValue(value)
+			if !ok || rate < 0 || rate > 100 {
+				continue
+			}
+			if !percent && rate < 1 {
+				rate *= 100
+			}
+			return rate, stat.Category, true
+		}
+	}
+	return 0, "", false
+}
+
+// feeComponent scores the entry fee for level. With a budget, cheaper than
+// the budget scores higher and fits is false when the fee exceeds it.
+func feeComponent(college *models.CollegeStats, level string, budget, lowest, highest int) (c models.ScoreComponent, fits bool) {
+	c = models.ScoreComponent{Name: "fees"}
+	fee, ok := entryFee(college.Fees, level)
+	if !ok {
+		c.Explanation = fmt.Sprintf("no %s fees reported", levelLabels[level])
+		if budget > 0 {
+			c.Explanation += ", so it may not fit the budget"
+		}
+		return c, true
+	}
+
+	c.Available = true
+	c.Value = fee
+	switch {
+	case budget > 0:
+		if fee > budget {
+			return c, false
+		}
+		c.Score = round3(1 - 0.5*float64(fee)/float64(budget))
+		c.Explanation = fmt.Sprintf("%s fees from %d a year, %.0f%% of the %d budget", levelLabels[level], fee, 100*float64(fee)/float64(budget), budget)
+	case highest > lowest:
+		c.Score = round3(1 - float64(fee-lowest)/float64(highest-lowest))
+		c.Explanation = fmt.Sprintf("%s fees from %d a year (candidates range from %d to %d)", levelLabels[level], fee, lowest, highest)
+	default:
+		c.Score = 1
+		c.Explanation = fmt.Sprintf("%s fees from %d a year", levelLabels[level], fee)
+	}
+	return c, true
+}
+
+func round3(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
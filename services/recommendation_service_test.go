@@ -0,0 +1,39 @@
+package services
+
+import (
+	"testing"
+
+	"gobackend/models"
+)
+
+func TestPlacementRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		value    interface{}
+		want     float64
+		ok       bool
+	}{
+		{"percent string", "Placement", "92%", 92, true},
+		{"percent string with space", "Employment after graduation", "87.5 %", 87.5, true},
+		{"one percent is not rescaled", "Placement", "1%", 1, true},
+		{"rate category number", "Placement rate", 85, 85, true},
+		{"rate category fraction", "Placement rate", 0.9, 90, true},
+		{"percentage category string", "Employment percentage", "78", 78, true},
+		{"salary is not a rate", "Median placement salary", 45, 0, false},
+		{"salary string is not a rate", "Average placement package", "45", 0, false},
+		{"over 100 percent", "Placement", "120%", 0, false},
+		{"unrelated category", "Acceptance rate", "12%", 0, false},
+		{"not a number", "Placement rate", "high", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			college := &models.CollegeStats{StudentStatistics: []models.StatisticItem{{Category: tt.category, Value: tt.value}}}
+			got, _, ok := placementRate(college)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("placementRate(%q: %v) = %v, %v; want %v, %v", tt.category, tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
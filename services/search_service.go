@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"regexp"
+
+	"gobackend/config"
+	"gobackend/models"
+	"gobackend/utils"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Snippet context kept on each side of the first match, in bytes
+const snippetRadius = 60
+
+// Maximum snippets returned for list fields such as departments
+const maxListHighlights = 3
+
+type scoredCollege struct {
+	models.CollegeStats `bson:",inline"`
+	Score               float64 `bson:"score"`
+}
+
+// SearchColleges runs a relevance-ranked full-text search over the
+// college_text_search index and returns one page of results with
+// highlighted snippets. page is 1-based.
+func SearchColleges(query string, page, limit int) (*models.SearchResponse, error) {
+	filter := activeFilter(bson.M{"$text": bson.M{"$search": query}})
+
+	total, err := config.CollegeCollection.CountDocuments(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().
+		SetProjection(bson.M{
+			"score":              bson.M{"$meta": "textScore"},
+			"student_statistics": 0,
+			"additional_details": 0,
+			"scholarships":       0,
+			"sources":            0,
+		}).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: 1}}).
+		SetSkip(int64((page - 1) * limit)).
+		SetLimit(int64(limit))
+
+	cursor, err := config.CollegeCollection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	matcher := utils.TermMatcher(utils.SearchTerms(query))
+	results := make([]models.SearchResult, 0, limit)
+	for cursor.Next(context.TODO()) {
+		var hit scoredCollege
+		if err := cursor.Decode(&hit); err != nil {
+			continue
+		}
+		results = append(results, models.SearchResult{
+			ID:            hit.ID.Hex(),
+			CollegeName:   hit.CollegeName,
+			Country:       hit.Country,
+			Location:      hit.Location,
+			GlobalRanking: hit.GlobalRanking,
+			Score:         hit.Score,
+			Highlights:    collegeHighlights(hit.CollegeStats, matcher),
+		})
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return &models.SearchResponse{
+		Query:   query,
+		Results: results,
+		Page:    page,
+		Limit:   limit,
+		Total:   total,
+	}, nil
+}
+
+// collegeHighlights collects snippets for every indexed field that contains
+// one of the search terms, keyed by the field's JSON name.
+func collegeHighlights(college models.CollegeStats, matcher *regexp.Regexp) map[string][]string {
+	highlights := make(map[string][]string)
+
+	for field, text := range map[string]string{
+		"college_name": college.CollegeName,
+		"location":     college.Location,
+		"about":        college.About,
+	} {
+		if snippet, ok := utils.HighlightSnippet(text, matcher, snippetRadius); ok {
+			highlights[field] = []string{snippet}
+		}
+	}
+
+	for field, items := range map[string][]string{
+		"departments":  college.Departments,
+		"ug_programs":  college.UGPrograms,
+		"pg_programs":  college.PGPrograms,
+		"phd_programs": college.PhDPrograms,
+	} {
+		for _, item := range items {
+			if snippet, ok := utils.HighlightSnippet(item, matcher, snippetRadius); ok {
+				highlights[field] = append(highlights[field], snippet)
+				if len(highlights[field]) == maxListHighlights {
+					break
+				}
+			}
+		}
+	}
+
+	return highlights
+}
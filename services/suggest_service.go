@@ -0,0 +1,329 @@
+package services
+
+import (
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"gobackend/models"
+)
+
+// Suggestion tuning
+const (
+	DefaultSuggestLimit = 8
+	MaxSuggestLimit     = 25
+
+	// minSuggestScore drops candidates that only share a trigram or two
+	minSuggestScore = 0.3
+	// correctionScore is the similarity a stored name needs before
+	// ResolveCollege uses it in place of a misspelled one.
+	correctionScore = 0.8
+	// correctionMargin is how far the best college must lead the runner-up
+	// for a correction to be trusted
+	correctionMargin = 0.1
+)
+
+var (
+	parenthetical = regexp.MustCompile(`\(([^)]*)\)`)
+	acronymSkip   = map[string]bool{"of": true, "and": true, "the": true, "for": true, "in": true, "at": true, "de": true}
+)
+
+// nameIndex is a trigram index over college names and aliases
+type nameIndex struct {
+	mu       sync.RWMutex
+	entries  map[int]*nameEntry
+	postings map[string]map[int]bool
+	colleges map[string][]int
+	nextID   int
+}
+
+type nameEntry struct {
+	collegeID   string
+	collegeName string
+	country     string
+	text        string
+	normalized  string
+	trigrams    []string
+	// derived marks aliases made up from the name, such as initials, which
+	// different colleges can share
+	derived bool
+}
+
+var suggestIndex = &nameIndex{
+	entries:  make(map[int]*nameEntry),
+	postings: make(map[string]map[int]bool),
+	colleges: make(map[string][]int),
+}
+
+// InitializeSuggestions loads every active college into the autocomplete
+// index and keeps it in sync with later writes.
+func InitializeSuggestions() {
+	OnCollegeChange(func(change CollegeChange) {
+		if change.Op == ChangeDelete || change.College == nil {
+			suggestIndex.remove(change.ID)
+			return
+		}
+		suggestIndex.put(change.College)
+	})
+
+	count, err := forEachActiveCollege(suggestIndex.put)
+	if err != nil {
+		log.Printf("⚠️ Suggestion index load failed: %v", err)
+		return
+	}
+	log.Printf("✅ Suggestion index built from %d colleges", count)
+}
+
+// CollegeAliases returns the stored aliases plus ones derived from the name:
+// a parenthesised short form, the name without it, the initials of its
+// significant words, and those initials followed by the last word.
+func CollegeAliases(college *models.CollegeStats) []string {
+	aliases := append([]string{}, college.Aliases...)
+
+	name := college.CollegeName
+	if match := parenthetical.FindStringSubmatch(name); match != nil {
+		aliases = append(aliases, strings.TrimSpace(match[1]))
+		name = strings.Join(strings.Fields(parenthetical.ReplaceAllString(name, " ")), " ")
+		aliases = append(aliases, name)
+	}
+
+	var words []string
+	for _, word := range strings.Fields(name) {
+		word = strings.Trim(word, ",.")
+		if word != "" && !acronymSkip[strings.ToLower(word)] && unicode.IsLetter([]rune(word)[0]) {
+			words = append(words, word)
+		}
+	}
+
+	var initials strings.Builder
+	for _, word := range words {
+		initials.WriteRune(unicode.ToUpper([]rune(word)[0]))
+	}
+	if len(words) >= 3 {
+		// "IIT Madras", "UC Berkeley"
+		last := words[len(words)-1]
+		aliases = append(aliases, string([]rune(initials.String())[:len(words)-1])+" "+last)
+	}
+	if initials.Len() >= 3 {
+		aliases = append(aliases, initials.String())
+	}
+
+	return aliases
+}
+
+// normalizeName lowercases text and reduces it to letters and digits
+// separated by single spaces.
+func normalizeName(text string) string {
+	return strings.TrimSpace(nonWord.ReplaceAllString(strings.ToLower(text), " "))
+}
+
+// nameTrigrams returns the distinct trigrams of each word, padded so that
+// word starts weigh more than word ends.
+func nameTrigrams(normalized string) []string {
+	seen := make(map[string]bool)
+	var trigrams []string
+	for _, word := range strings.Fields(normalized) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			t := string(runes[i : i+3])
+			if !seen[t] {
+				seen[t] = true
+				trigrams = append(trigrams, t)
+			}
+		}
+	}
+	return trigrams
+}
+
+func (idx *nameIndex) put(college *models.CollegeStats) {
+	id := college.ID.Hex()
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.removeLocked(id)
+
+	// CollegeAliases lists the stored aliases before the derived ones
+	stored := 1 + len(college.Aliases)
+
+	seen := make(map[string]bool)
+	for i, text := range append([]string{college.CollegeName}, CollegeAliases(college)...) {
+		normalized := normalizeName(text)
+		if normalized == "" || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+
+		entry := &nameEntry{
+			collegeID:   id,
+			collegeName: college.CollegeName,
+			country:     college.Country,
+			text:        text,
+			normalized:  normalized,
+			trigrams:    nameTrigrams(normalized),
+			derived:     i >= stored,
+		}
+		idx.nextID++
+		idx.entries[idx.nextID] = entry
+		idx.colleges[id] = append(idx.colleges[id], idx.nextID)
+		for _, t := range entry.trigrams {
+			if idx.postings[t] == nil {
+				idx.postings[t] = make(map[int]bool)
+			}
+			idx.postings[t][idx.nextID] = true
+		}
+	}
+}
+
+func (idx *nameIndex) remove(id string) {
+	idx.mu.Lock()
+	idx.removeLocked(id)
+	idx.mu.Unlock()
+}
+
+func (idx *nameIndex) removeLocked(id string) {
+	for _, entryID := range idx.colleges[id] {
+		for _, t := range idx.entries[entryID].trigrams {
+			delete(idx.postings[t], entryID)
+			if len(idx.postings[t]) == 0 {
+				delete(idx.postings, t)
+			}
+		}
+		delete(idx.entries, entryID)
+	}
+	delete(idx.colleges, id)
+}
+
+// scored is a candidate entry with its trigram overlap
+type scored struct {
+	entry  *nameEntry
+	shared int
+}
+
+// candidates returns every entry sharing at least one trigram with the query
+func (idx *nameIndex) candidates(trigrams []string) []scored {
+	counts := make(map[int]int)
+	for _, t := range trigrams {
+		for entryID := range idx.postings[t] {
+			counts[entryID]++
+		}
+	}
+
+	results := make([]scored, 0, len(counts))
+	for entryID, shared := range counts {
+		results = append(results, scored{entry: idx.entries[entryID], shared: shared})
+	}
+	return results
+}
+
+// SuggestColleges returns up to limit colleges whose name or alias resembles
+// what the user has typed so far, best first.
+func SuggestColleges(query string, limit int) []models.Suggestion {
+	normalized := normalizeName(query)
+	trigrams := nameTrigrams(normalized)
+	if len(trigrams) == 0 {
+		return []models.Suggestion{}
+	}
+
+	suggestIndex.mu.RLock()
+	best := make(map[string]models.Suggestion)
+	for _, c := range suggestIndex.candidates(trigrams) {
+		score := suggestionScore(normalized, len(trigrams), c)
+		if score < minSuggestScore {
+			continue
+		}
+		if current, ok := best[c.entry.collegeID]; ok && current.Score >= score {
+			continue
+		}
+		best[c.entry.collegeID] = models.Suggestion{
+			ID:          c.entry.collegeID,
+			CollegeName: c.entry.collegeName,
+			Country:     c.entry.country,
+			Matched:     c.entry.text,
+			Score:       score,
+		}
+	}
+	suggestIndex.mu.RUnlock()
+
+	results := make([]models.Suggestion, 0, len(best))
+	for _, s := range best {
+		results = append(results, s)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].CollegeName < results[j].CollegeName
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// suggestionScore favours entries that contain most of what was typed (so
+// partial input ranks well against long names), with a bonus when the entry
+// or one of its words starts with the query.
+func suggestionScore(normalized string, queryTrigrams int, c scored) float64 {
+	coverage := float64(c.shared) / float64(queryTrigrams)
+	jaccard := float64(c.shared) / float64(queryTrigrams+len(c.entry.trigrams)-c.shared)
+	score := 0.7*coverage + 0.3*jaccard
+
+	switch {
+	case c.entry.normalized == normalized:
+		score = 1
+	case strings.HasPrefix(c.entry.normalized, normalized):
+		score += 0.15
+	case strings.Contains(" "+c.entry.normalized, " "+normalized):
+		score += 0.05
+	}
+	if score > 1 {
+		score = 1
+	}
+	return float64(int(score*1000+0.5)) / 1000
+}
+
+// CorrectCollegeName returns the ID of the stored college whose name or
+// stored alias is a near match for name, so misspellings resolve to existing
+// records. Derived aliases are ignored, and so is a match that doesn't clearly
+// beat the next closest college: guessing wrong would serve another college's
+// data where Gemini would have found the right one.
+func CorrectCollegeName(name string) (string, bool) {
+	normalized := normalizeName(name)
+	trigrams := nameTrigrams(normalized)
+	if len(trigrams) == 0 {
+		return "", false
+	}
+
+	suggestIndex.mu.RLock()
+	defer suggestIndex.mu.RUnlock()
+
+	scores := make(map[string]float64)
+	for _, c := range suggestIndex.candidates(trigrams) {
+		if c.entry.derived {
+			continue
+		}
+		jaccard := float64(c.shared) / float64(len(trigrams)+len(c.entry.trigrams)-c.shared)
+		if jaccard > scores[c.entry.collegeID] {
+			scores[c.entry.collegeID] = jaccard
+		}
+	}
+
+	bestID, bestScore, runnerUp := "", 0.0, 0.0
+	for id, score := range scores {
+		switch {
+		case score > bestScore:
+			bestID, bestScore, runnerUp = id, score, bestScore
+		case score > runnerUp:
+			runnerUp = score
+		}
+	}
+	if bestScore < correctionScore || bestScore-runnerUp < correctionMargin {
+		return "", false
+	}
+	return bestID, true
+}
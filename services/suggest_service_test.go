@@ -0,0 +1,60 @@
+package services
+
+import (
+	"testing"
+
+	"gobackend/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// withSuggestIndex indexes colleges in place of the live suggestion index
+// until the test ends
+func withSuggestIndex(t *testing.T, colleges ...*models.CollegeStats) {
+	t.Helper()
+	saved := suggestIndex
+	t.Cleanup(func() { suggestIndex = saved })
+
+	suggestIndex = &nameIndex{
+		entries:  make(map[int]*nameEntry),
+		postings: make(map[string]map[int]bool),
+		colleges: make(map[string][]int),
+	}
+	for _, college := range colleges {
+		suggestIndex.put(college)
+	}
+}
+
+func TestCorrectCollegeName(t *testing.T) {
+	manipal := &models.CollegeStats{ID: primitive.NewObjectID(), CollegeName: "Manipal Institute of Technology"}
+	madras := &models.CollegeStats{ID: primitive.NewObjectID(), CollegeName: "Madras Institute of Technology"}
+	stanford := &models.CollegeStats{ID: primitive.NewObjectID(), CollegeName: "Stanford University", Aliases: []string{"Leland Stanford Junior University"}}
+	withSuggestIndex(t, manipal, madras, stanford)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Manipal Institue of Technology", manipal.ID.Hex()},
+		{"Leland Stanford Junior Universty", stanford.ID.Hex()},
+		// Both colleges share these initials, and initials are never used
+		{"MIT", ""},
+		{"MI Technology", ""},
+		// Equally close to two colleges
+		{"Ma Institute of Technology", ""},
+		{"Harvard University", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CorrectCollegeName(tt.name)
+			if ok != (tt.want != "") || got != tt.want {
+				t.Errorf("CorrectCollegeName(%q) = %q, %v; want %q", tt.name, got, ok, tt.want)
+			}
+		})
+	}
+
+	if results := SuggestColleges("MIT", 5); len(results) != 2 {
+		t.Errorf("SuggestColleges(MIT) returned %d colleges, want both by their initials", len(results))
+	}
+}
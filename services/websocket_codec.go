@@ -0,0 +1,73 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// WebSocket subprotocols a client can request with Sec-WebSocket-Protocol.
+// Without one, messages are JSON text frames.
+const (
+	JSONSubprotocol    = "json"
+	MsgpackSubprotocol = "msgpack"
+)
+
+// WebSocketSubprotocols lists the supported subprotocols, preferred first
+var WebSocketSubprotocols = []string{MsgpackSubprotocol, JSONSubprotocol}
+
+// jsonToMsgpack re-encodes a JSON event as MessagePack. Whole numbers become
+// MessagePack integers rather than floats.
+func jsonToMsgpack(payload []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := msgpack.NewEncoder(&buf)
+	encoder.UseCompactInts(true)
+	if err := encoder.Encode(msgpackValue(value)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// msgpackValue replaces the json.Numbers in value with int64 or float64
+func msgpackValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = msgpackValue(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = msgpackValue(item)
+		}
+	}
+	return value
+}
+
+// msgpackToJSON decodes a MessagePack command so HandleClientMessage can read
+// it like a JSON one
+func msgpackToJSON(payload []byte) ([]byte, error) {
+	var value interface{}
+	if err := msgpack.Unmarshal(payload, &value); err != nil {
+		return nil, err
+	}
+	if _, ok := value.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("expected a map, got %T", value)
+	}
+	return json.Marshal(value)
+}
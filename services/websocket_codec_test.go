@@ -0,0 +1,65 @@
+package services
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestJSONToMsgpack(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want []byte
+	}{
+		{"whole number is a fixint", `{"n":1}`, []byte{0x81, 0xa1, 'n', 0x01}},
+		{"negative number is a fixint", `{"n":-1}`, []byte{0x81, 0xa1, 'n', 0xff}},
+		{"fraction is a float64", `{"n":1.5}`, []byte{0x81, 0xa1, 'n', 0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{"nested array", `{"a":[true,null]}`, []byte{0x81, 0xa1, 'a', 0x92, 0xc3, 0xc0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsonToMsgpack([]byte(tt.json))
+			if err != nil {
+				t.Fatalf("jsonToMsgpack: %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("jsonToMsgpack(%s) = % x, want % x", tt.json, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := jsonToMsgpack([]byte(`{"n":`)); err == nil {
+		t.Error("jsonToMsgpack accepted truncated JSON")
+	}
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	// Keys are sorted, as json.Marshal writes them, and the id is too large
+	// to survive a trip through float64
+	const event = `{"data":{"fees":{"ug_yearly_min":250000},"id":9007199254740993,"tags":["a","b"]},"seq":42,"type":"college_updated"}`
+
+	encoded, err := jsonToMsgpack([]byte(event))
+	if err != nil {
+		t.Fatalf("jsonToMsgpack: %v", err)
+	}
+	decoded, err := msgpackToJSON(encoded)
+	if err != nil {
+		t.Fatalf("msgpackToJSON: %v", err)
+	}
+	if string(decoded) != event {
+		t.Errorf("round trip = %s, want %s", decoded, event)
+	}
+}
+
+func TestMsgpackToJSONRejectsNonMaps(t *testing.T) {
+	payload, _ := msgpack.Marshal([]string{"subscribe"})
+	if _, err := msgpackToJSON(payload); err == nil {
+		t.Error("msgpackToJSON accepted an array")
+	}
+	if _, err := msgpackToJSON([]byte{0xc1}); err == nil {
+		t.Error("msgpackToJSON accepted an invalid payload")
+	}
+}
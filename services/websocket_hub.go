@@ -0,0 +1,479 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gobackend/models"
+
+	"github.com/gorilla/websocket"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// wsWriteWait bounds a single frame write to a client
+	wsWriteWait = 10 * time.Second
+	// wsPongWait is how long a client may stay silent before it is dropped
+	wsPongWait = 60 * time.Second
+	// wsPingPeriod must stay below wsPongWait so pongs arrive in time
+	wsPingPeriod = 25 * time.Second
+	// wsSendRetry is how often SendWait checks for room in the queue
+	wsSendRetry = 10 * time.Millisecond
+)
+
+// Subscriber receives hub events over a WebSocket or an SSE stream. Events
+// are queued on send without blocking the publisher; WS_QUEUE_POLICY decides
+// what happens when the queue is full. For WebSockets, WritePump is the only
+// goroutine that writes to conn, since gorilla/websocket allows one
+// concurrent writer and pings must be serialized with data frames.
+type Subscriber struct {
+	conn      *websocket.Conn // nil for Server-Sent Events subscribers
+	label     string
+	send      chan Event
+	done      chan struct{}
+	closeOnce sync.Once
+	// queueMu serializes producers so a full queue can be trimmed safely
+	queueMu sync.Mutex
+	// msgpack is set when the client negotiated the MessagePack subprotocol
+	msgpack bool
+	// fetches counts fetch_college commands still running
+	fetches atomic.Int32
+}
+
+// NewWsClient wraps conn; label identifies the client in logs. Messages are
+// sent as MessagePack binary frames if the client negotiated that
+// subprotocol, and as JSON text frames otherwise.
+func NewWsClient(conn *websocket.Conn, label string) *Subscriber {
+	client := NewSubscriber(label)
+	client.conn = conn
+	client.msgpack = conn.Subprotocol() == MsgpackSubprotocol
+	return client
+}
+
+// NewSubscriber creates a subscriber whose owner drains Events itself, as the
+// SSE handler does
+func NewSubscriber(label string) *Subscriber {
+	return &Subscriber{
+		label: label,
+		send:  make(chan Event, realtimeLimits.queueSize),
+		done:  make(chan struct{}),
+	}
+}
+
+// Events delivers queued events in order
+func (c *Subscriber) Events() <-chan Event {
+	return c.send
+}
+
+// Done is closed once the subscriber has been closed
+func (c *Subscriber) Done() <-chan struct{} {
+	return c.done
+}
+
+// Send queues message as JSON without blocking
+func (c *Subscriber) Send(message interface{}) bool {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("❌ Error encoding message for %s: %v", c.label, err)
+		return false
+	}
+	return c.enqueue(Event{Payload: payload})
+}
+
+// SendWait queues message once the queue is at most half full, leaving room
+// for live events, instead of applying the queue policy. Snapshot pages use
+// it so a large snapshot is neither dropped part-way nor gets its client
+// evicted. It gives up when the client is closed.
+func (c *Subscriber) SendWait(message interface{}) bool {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("❌ Error encoding message for %s: %v", c.label, err)
+		return false
+	}
+	event := Event{Payload: payload}
+
+	for {
+		c.queueMu.Lock()
+		if len(c.send) <= cap(c.send)/2 {
+			c.send <- event
+			c.queueMu.Unlock()
+			return true
+		}
+		c.queueMu.Unlock()
+
+		select {
+		case <-c.done:
+			return false
+		case <-time.After(wsSendRetry):
+		}
+	}
+}
+
+// enqueue queues event, applying the queue policy when the subscriber has
+// fallen realtimeLimits.queueSize events behind. A dropped event leaves a
+// gap in the sequence numbers the client sees.
+func (c *Subscriber) enqueue(event Event) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+
+	c.queueMu.Lock()
+	defer c.queueMu.Unlock()
+
+	select {
+	case c.send <- event:
+		return true
+	default:
+	}
+
+	switch realtimeLimits.queuePolicy {
+	case QueueCoalesce:
+		if !c.coalesceLocked(event.Key) {
+			c.dropOldestLocked()
+		}
+	case QueueDropOldest:
+		c.dropOldestLocked()
+	default:
+		log.Printf("⚠️ Subscriber %s is %d messages behind, disconnecting", c.label, cap(c.send))
+		realtimeStats.evicted.Add(1)
+		c.Close()
+		return false
+	}
+
+	// Only queueMu holders add to send, so there is room now
+	select {
+	case c.send <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// dropOldestLocked discards the event at the head of the queue
+func (c *Subscriber) dropOldestLocked() {
+	select {
+	case <-c.send:
+		realtimeStats.dropped.Add(1)
+	default:
+	}
+}
+
+// coalesceLocked removes queued events with key, which a newer event
+// supersedes, and reports whether any were removed. The consumer keeps
+// reading meanwhile, so an event it takes mid-drain can overtake older ones;
+// clients order by seq.
+func (c *Subscriber) coalesceLocked(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	kept := make([]Event, 0, len(c.send))
+	removed := 0
+drain:
+	for range cap(c.send) {
+		select {
+		case queued := <-c.send:
+			if queued.Key == key {
+				removed++
+			} else {
+				kept = append(kept, queued)
+			}
+		default:
+			break drain
+		}
+	}
+	for _, queued := range kept {
+		c.send <- queued
+	}
+
+	realtimeStats.coalesced.Add(int64(removed))
+	return removed > 0
+}
+
+// Close stops the subscriber. For WebSockets the write pump then closes the
+// connection, which ends the read pump too. It is safe to call more than once.
+func (c *Subscriber) Close() {
+	c.closeOnce.Do(func() { close(c.done) })
+}
+
+// WritePump writes backlog (events replayed by SubscribeSince, or nil), then
+// queued messages and periodic pings until the client is closed or a write
+// fails. It is the only goroutine that writes to conn.
+func (c *Subscriber) WritePump(backlog []Event) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for _, event := range backlog {
+		if err := c.writeEvent(event); err != nil {
+			log.Printf("❌ WebSocket write error for %s: %v", c.label, err)
+			c.Close()
+			return
+		}
+	}
+
+	for {
+		select {
+		case event := <-c.send:
+			if err := c.writeEvent(event); err != nil {
+				log.Printf("❌ WebSocket write error for %s: %v", c.label, err)
+				c.Close()
+				return
+			}
+
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				log.Printf("❌ WebSocket ping error for %s: %v", c.label, err)
+				c.Close()
+				return
+			}
+
+		case <-c.done:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		}
+	}
+}
+
+// writeEvent writes one event in the client's encoding. An event that cannot
+// be converted to MessagePack is skipped rather than ending the connection.
+func (c *Subscriber) writeEvent(event Event) error {
+	messageType, payload := websocket.TextMessage, event.Payload
+	if c.msgpack {
+		encoded, err := jsonToMsgpack(event.Payload)
+		if err != nil {
+			log.Printf("❌ Error encoding MessagePack for %s: %v", c.label, err)
+			return nil
+		}
+		messageType, payload = websocket.BinaryMessage, encoded
+	}
+
+	c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return c.conn.WriteMessage(messageType, payload)
+}
+
+// ReadPump reads frames until the connection fails, goes quiet for longer
+// than wsPongWait or sends a frame over WS_MAX_MESSAGE_BYTES, passing each one
+// to handle (which may be nil) as JSON. The client is closed when it returns.
+func (c *Subscriber) ReadPump(handle func(payload []byte)) {
+	defer c.Close()
+
+	c.conn.SetReadLimit(realtimeLimits.maxMessageBytes)
+	c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		return nil
+	})
+
+	for {
+		messageType, payload, err := c.conn.ReadMessage()
+		if err != nil {
+			if errors.Is(err, websocket.ErrReadLimit) {
+				realtimeStats.oversized.Add(1)
+				log.Printf("⚠️ %s sent a message over %d bytes, disconnecting", c.label, realtimeLimits.maxMessageBytes)
+				return
+			}
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure, websocket.CloseNoStatusReceived) {
+				log.Printf("❌ WebSocket error for %s: %v", c.label, err)
+			}
+			return
+		}
+		c.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+		if c.msgpack && messageType == websocket.BinaryMessage {
+			if payload, err = msgpackToJSON(payload); err != nil {
+				c.Send(models.WebSocketMessage{
+					Type:    models.WsError,
+					Code:    models.ErrCodeBadRequest,
+					Message: "binary messages must be MessagePack maps with a type",
+				})
+				continue
+			}
+		}
+		if handle != nil {
+			handle(payload)
+		}
+	}
+}
+
+// GlobalTopic receives every college event
+const GlobalTopic = "global"
+
+// MaxClientTopics caps how many topics one subscriber may follow
+const MaxClientTopics = 100
+
+var errTooManyTopics = fmt.Errorf("a connection may subscribe to at most %d topics", MaxClientTopics)
+
+// CountryTopic is the topic for events about colleges in country
+func CountryTopic(country string) string {
+	return "country:" + strings.ToLower(strings.TrimSpace(country))
+}
+
+// CollegeTopic is the topic for events about the college with the given id
+func CollegeTopic(id string) string {
+	return "college:" + strings.ToLower(id)
+}
+
+// ParseTopic validates a topic sent by a client and returns its canonical
+// form: "global", "countries", "country:<name>" or "college:<id>".
+func ParseTopic(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if strings.EqualFold(raw, GlobalTopic) {
+		return GlobalTopic, nil
+	}
+	if strings.EqualFold(raw, CountriesTopic) {
+		return CountriesTopic, nil
+	}
+
+	kind, value, ok := strings.Cut(raw, ":")
+	value = strings.TrimSpace(value)
+	if !ok || value == "" {
+		return "", fmt.Errorf("topic %q must be global, countries, country:<name> or college:<id>", raw)
+	}
+
+	switch strings.ToLower(kind) {
+	case "country":
+		return CountryTopic(value), nil
+	case "college":
+		if _, err := primitive.ObjectIDFromHex(value); err != nil {
+			return "", fmt.Errorf("topic %q: %w", raw, ErrInvalidID)
+		}
+		return CollegeTopic(value), nil
+	}
+	return "", fmt.Errorf("unknown topic kind %q; use global, country or college", kind)
+}
+
+// wsHub tracks which subscribers follow which topics. Broadcasts only enqueue,
+// so holding mu never waits on the network.
+type wsHub struct {
+	mu     sync.RWMutex
+	topics map[string]map[*Subscriber]bool
+	// subscriptions is the reverse index, for listing and cleanup
+	subscriptions map[*Subscriber]map[string]bool
+}
+
+var hub = &wsHub{
+	topics:        make(map[string]map[*Subscriber]bool),
+	subscriptions: make(map[*Subscriber]map[string]bool),
+}
+
+// Subscribe adds client to the recipients of topic, which must already be in
+// canonical form (see ParseTopic).
+func Subscribe(client *Subscriber, topic string) error {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	subs := hub.subscriptions[client]
+	if subs[topic] {
+		return nil
+	}
+	if len(subs) >= MaxClientTopics {
+		return errTooManyTopics
+	}
+	if subs == nil {
+		subs = make(map[string]bool)
+		hub.subscriptions[client] = subs
+	}
+	subs[topic] = true
+
+	if hub.topics[topic] == nil {
+		hub.topics[topic] = make(map[*Subscriber]bool)
+	}
+	hub.topics[topic][client] = true
+	return nil
+}
+
+// Unsubscribe removes client from topic; it is a no-op if it wasn't subscribed
+func Unsubscribe(client *Subscriber, topic string) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	hub.unsubscribeLocked(client, topic)
+}
+
+func (h *wsHub) unsubscribeLocked(client *Subscriber, topic string) {
+	delete(h.subscriptions[client], topic)
+	if len(h.subscriptions[client]) == 0 {
+		delete(h.subscriptions, client)
+	}
+	delete(h.topics[topic], client)
+	if len(h.topics[topic]) == 0 {
+		delete(h.topics, topic)
+	}
+}
+
+// Subscriptions lists client's topics in sorted order
+func Subscriptions(client *Subscriber) []string {
+	hub.mu.RLock()
+	topics := make([]string, 0, len(hub.subscriptions[client]))
+	for topic := range hub.subscriptions[client] {
+		topics = append(topics, topic)
+	}
+	hub.mu.RUnlock()
+
+	sort.Strings(topics)
+	return topics
+}
+
+// RegisterClient subscribes client to country's events
+func RegisterClient(country string, client *Subscriber) {
+	Subscribe(client, CountryTopic(country))
+}
+
+// UnregisterClient drops every subscription client holds
+func UnregisterClient(client *Subscriber) {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+
+	for topic := range hub.subscriptions[client] {
+		hub.unsubscribeLocked(client, topic)
+	}
+}
+
+// deliverLocal records message in this instance's event log and queues it
+// once for every local subscriber of any of topics. The log lock is held
+// while queueing so subscribers see events in sequence order.
+func deliverLocal(msg BusMessage) {
+	eventLog.Lock()
+	defer eventLog.Unlock()
+
+	event, err := recordEventLocked(msg)
+	if err != nil {
+		log.Printf("❌ Error encoding %v broadcast: %v", msg.Message["type"], err)
+		return
+	}
+
+	hub.mu.RLock()
+	defer hub.mu.RUnlock()
+
+	sent := make(map[*Subscriber]bool)
+	for _, topic := range msg.Topics {
+		for client := range hub.topics[topic] {
+			if !sent[client] {
+				sent[client] = true
+				client.enqueue(event)
+			}
+		}
+	}
+}
+
+// broadcastCollegeMessage delivers a college event to followers of the
+// college's country, of the college itself and of the global topic.
+func broadcastCollegeMessage(country, collegeID string, message map[string]interface{}) {
+	topics := []string{GlobalTopic, CountryTopic(country)}
+	if collegeID != "" {
+		topics = append(topics, CollegeTopic(collegeID))
+	}
+	publish(BusMessage{Topics: topics, Message: message})
+}
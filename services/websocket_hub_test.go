@@ -0,0 +1,75 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+// queueSubscriber returns a subscriber with a queue of size events handled
+// by policy, restoring the limits when the test ends
+func queueSubscriber(t *testing.T, size int, policy string) *Subscriber {
+	t.Helper()
+	saved := realtimeLimits
+	t.Cleanup(func() { realtimeLimits = saved })
+
+	realtimeLimits.queueSize = size
+	realtimeLimits.queuePolicy = policy
+	return NewSubscriber("test")
+}
+
+// queuedSeqs drains client's queue
+func queuedSeqs(client *Subscriber) []uint64 {
+	var seqs []uint64
+	for len(client.send) > 0 {
+		seqs = append(seqs, (<-client.send).Seq)
+	}
+	return seqs
+}
+
+func TestSubscriberQueuePolicies(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+		keys   []string
+		want   []uint64
+	}{
+		{"drop oldest", QueueDropOldest, []string{"a", "a", "a"}, []uint64{2, 3}},
+		{"coalesce by key", QueueCoalesce, []string{"a", "b", "a"}, []uint64{2, 3}},
+		{"coalesce every match", QueueCoalesce, []string{"a", "a", "b", "a"}, []uint64{3, 4}},
+		{"coalesce without a match drops oldest", QueueCoalesce, []string{"a", "b", "c"}, []uint64{2, 3}},
+		{"coalesce without a key drops oldest", QueueCoalesce, []string{"", "", ""}, []uint64{2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size := len(tt.keys) - 1
+			client := queueSubscriber(t, size, tt.policy)
+			for i, key := range tt.keys {
+				if !client.enqueue(Event{Seq: uint64(i + 1), Key: key}) {
+					t.Fatalf("event %d was not queued", i+1)
+				}
+			}
+			if got := queuedSeqs(client); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("queued %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriberQueueDisconnect(t *testing.T) {
+	client := queueSubscriber(t, 2, QueueDisconnect)
+	client.enqueue(Event{Seq: 1})
+	client.enqueue(Event{Seq: 2})
+
+	if client.enqueue(Event{Seq: 3}) {
+		t.Error("event queued on a full queue")
+	}
+	select {
+	case <-client.Done():
+	default:
+		t.Fatal("subscriber still open after overflowing its queue")
+	}
+	if client.enqueue(Event{Seq: 4}) {
+		t.Error("event queued on a closed subscriber")
+	}
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"gobackend/models"
+)
+
+// What a subscriber's full outbound queue does with the next event
+const (
+	// QueueDisconnect closes the slow client; it can reconnect with ?since=
+	QueueDisconnect = "disconnect"
+	// QueueDropOldest discards the oldest queued event to make room
+	QueueDropOldest = "drop_oldest"
+	// QueueCoalesce discards queued events the new one supersedes (see
+	// Event.Key), falling back to dropping the oldest
+	QueueCoalesce = "coalesce"
+)
+
+// Errors returned by AcquireConnection
+var (
+	ErrTooManyConnections = errors.New("server is at its real-time connection limit")
+	ErrTooManyFromAddress = errors.New("too many real-time connections from this address")
+)
+
+// realtimeLimits are read once by InitializeRealtimeLimits; the defaults
+// apply until then
+var realtimeLimits = struct {
+	maxConnections  int
+	maxPerAddress   int
+	queueSize       int
+	queuePolicy     string
+	maxMessageBytes int64
+	// snapshotPageSize is how many colleges or countries go in one
+	// snapshot_page frame
+	snapshotPageSize int
+	allowedOrigins   map[string]bool // nil allows every origin
+	// proxyHops is how many trusted proxies append to X-Forwarded-For; 0
+	// ignores the header
+	proxyHops int
+}{
+	maxConnections:   10000,
+	maxPerAddress:    50,
+	queueSize:        256,
+	queuePolicy:      QueueDisconnect,
+	maxMessageBytes:  4096,
+	snapshotPageSize: 100,
+}
+
+// realtimeStats counts WebSocket and SSE activity for /api/health
+var realtimeStats struct {
+	connections atomic.Int64
+	rejected    atomic.Int64
+	evicted     atomic.Int64
+	dropped     atomic.Int64
+	coalesced   atomic.Int64
+	oversized   atomic.Int64
+}
+
+var (
+	connectionsByAddress   = make(map[string]int)
+	connectionsByAddressMu sync.Mutex
+)
+
+// InitializeRealtimeLimits reads the WS_* settings that bound WebSocket and
+// SSE connections
+func InitializeRealtimeLimits() {
+	if n, err := strconv.Atoi(os.Getenv("WS_MAX_CONNECTIONS")); err == nil && n > 0 {
+		realtimeLimits.maxConnections = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("WS_MAX_CONNECTIONS_PER_IP")); err == nil && n > 0 {
+		realtimeLimits.maxPerAddress = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("WS_SEND_QUEUE")); err == nil && n > 0 {
+		realtimeLimits.queueSize = n
+	}
+	if n, err := strconv.Atoi(os.Getenv("WS_SNAPSHOT_PAGE_SIZE")); err == nil && n > 0 {
+		realtimeLimits.snapshotPageSize = min(n, 1000)
+	}
+	if n, err := strconv.ParseInt(os.Getenv("WS_MAX_MESSAGE_BYTES"), 10, 64); err == nil && n > 0 {
+		realtimeLimits.maxMessageBytes = n
+	}
+
+	switch policy := os.Getenv("WS_QUEUE_POLICY"); policy {
+	case "":
+	case QueueDisconnect, QueueDropOldest, QueueCoalesce:
+		realtimeLimits.queuePolicy = policy
+	default:
+		log.Printf("⚠️ Unknown WS_QUEUE_POLICY %q, using %s", policy, realtimeLimits.queuePolicy)
+	}
+
+	if raw := strings.TrimSpace(os.Getenv("WS_ALLOWED_ORIGINS")); raw != "" && raw != "*" {
+		realtimeLimits.allowedOrigins = make(map[string]bool)
+		for _, origin := range strings.Split(raw, ",") {
+			if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+				realtimeLimits.allowedOrigins[strings.ToLower(origin)] = true
+			}
+		}
+	}
+	if os.Getenv("WS_TRUST_PROXY") == "true" {
+		realtimeLimits.proxyHops = 1
+		if n, err := strconv.Atoi(os.Getenv("WS_PROXY_HOPS")); err == nil && n > 0 {
+			realtimeLimits.proxyHops = n
+		}
+	}
+
+	log.Printf("✅ Real-time limits: %d connections (%d per IP), queue %d (%s), messages up to %d bytes",
+		realtimeLimits.maxConnections, realtimeLimits.maxPerAddress, realtimeLimits.queueSize,
+		realtimeLimits.queuePolicy, realtimeLimits.maxMessageBytes)
+}
+
+// CheckOrigin allows browsers from WS_ALLOWED_ORIGINS (every origin when it
+// is unset or "*"). Requests without an Origin header come from non-browser
+// clients and are allowed.
+func CheckOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || realtimeLimits.allowedOrigins == nil {
+		return true
+	}
+	if realtimeLimits.allowedOrigins[strings.ToLower(strings.TrimRight(origin, "/"))] {
+		return true
+	}
+
+	realtimeStats.rejected.Add(1)
+	if u, err := url.Parse(origin); err == nil {
+		log.Printf("⚠️ Rejected real-time connection from origin %s", u.Host)
+	}
+	return false
+}
+
+// AcquireConnection reserves a connection slot for r's client address. Call
+// release when the connection ends.
+func AcquireConnection(r *http.Request) (release func(), err error) {
+	address := clientAddress(r)
+
+	connectionsByAddressMu.Lock()
+	defer connectionsByAddressMu.Unlock()
+
+	if realtimeStats.connections.Load() >= int64(realtimeLimits.maxConnections) {
+		realtimeStats.rejected.Add(1)
+		return nil, ErrTooManyConnections
+	}
+	if connectionsByAddress[address] >= realtimeLimits.maxPerAddress {
+		realtimeStats.rejected.Add(1)
+		return nil, ErrTooManyFromAddress
+	}
+
+	connectionsByAddress[address]++
+	realtimeStats.connections.Add(1)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			connectionsByAddressMu.Lock()
+			if connectionsByAddress[address]--; connectionsByAddress[address] <= 0 {
+				delete(connectionsByAddress, address)
+			}
+			connectionsByAddressMu.Unlock()
+			realtimeStats.connections.Add(-1)
+		})
+	}, nil
+}
+
+// clientAddress is the client IP. Behind WS_PROXY_HOPS trusted proxies it is
+// the X-Forwarded-For entry the outermost of them added, counting from the
+// right: entries further left come from the client and can be forged.
+func clientAddress(r *http.Request) string {
+	if hops := realtimeLimits.proxyHops; hops > 0 {
+		var entries []string
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			for _, entry := range strings.Split(header, ",") {
+				if entry = strings.TrimSpace(entry); entry != "" {
+					entries = append(entries, entry)
+				}
+			}
+		}
+		if len(entries) >= hops {
+			return entries[len(entries)-hops]
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// RealtimeStats reports current connections and the limit counters
+func RealtimeStats() models.RealtimeStats {
+	return models.RealtimeStats{
+		Connections: realtimeStats.connections.Load(),
+		Rejected:    realtimeStats.rejected.Load(),
+		Evicted:     realtimeStats.evicted.Load(),
+		Dropped:     realtimeStats.dropped.Load(),
+		Coalesced:   realtimeStats.coalesced.Load(),
+		Oversized:   realtimeStats.oversized.Load(),
+		QueuePolicy: realtimeLimits.queuePolicy,
+		QueueSize:   realtimeLimits.queueSize,
+	}
+}
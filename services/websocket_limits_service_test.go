@@ -0,0 +1,39 @@
+package services
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		proxyHops int
+		forwarded []string
+		want      string
+	}{
+		{"header ignored without a trusted proxy", 0, []string{"203.0.113.9"}, "192.0.2.1"},
+		{"rightmost entry", 1, []string{"198.51.100.7, 203.0.113.9"}, "203.0.113.9"},
+		{"forged entries skipped", 2, []string{"10.0.0.1, 198.51.100.7, 203.0.113.9"}, "198.51.100.7"},
+		{"repeated headers", 2, []string{"198.51.100.7", "203.0.113.9"}, "198.51.100.7"},
+		{"too few entries", 2, []string{"203.0.113.9"}, "192.0.2.1"},
+		{"no header", 1, nil, "192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := realtimeLimits
+			t.Cleanup(func() { realtimeLimits = saved })
+			realtimeLimits.proxyHops = tt.proxyHops
+
+			r := httptest.NewRequest("GET", "/ws/colleges", nil)
+			r.RemoteAddr = "192.0.2.1:5000"
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			if got := clientAddress(r); got != tt.want {
+				t.Errorf("clientAddress = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"gobackend/models"
+)
+
+// maxClientFetches is how many fetch_college commands one connection may
+// have running at once
+const maxClientFetches = 2
+
+// HandleClientMessage runs one command sent by a WebSocket client. Every
+// command gets a reply echoing its id: an acknowledgement, the requested
+// data, or an error.
+func HandleClientMessage(client *Subscriber, payload []byte) {
+	var cmd models.WebSocketMessage
+	if err := json.Unmarshal(payload, &cmd); err != nil {
+		client.Send(models.WebSocketMessage{
+			Type:    models.WsError,
+			Code:    models.ErrCodeBadRequest,
+			Message: "messages must be JSON objects with a type",
+		})
+		return
+	}
+
+	topics := commandTopics(cmd)
+	if len(topics) == 0 && (cmd.Type == models.WsSubscribe || cmd.Type == models.WsUnsubscribe) {
+		sendCommandError(client, cmd.ID, models.ErrCodeValidation, "", cmd.Type+" needs a topic or topics")
+		return
+	}
+
+	switch cmd.Type {
+	case models.WsSubscribe:
+		for _, raw := range topics {
+			subscribeClient(client, cmd.ID, raw)
+		}
+
+	case models.WsUnsubscribe:
+		for _, raw := range topics {
+			topic, err := ParseTopic(raw)
+			if err != nil {
+				sendCommandError(client, cmd.ID, models.ErrCodeValidation, raw, err.Error())
+				continue
+			}
+			Unsubscribe(client, topic)
+			client.Send(models.WebSocketMessage{Type: models.WsUnsubscribed, ID: cmd.ID, Topic: topic})
+		}
+
+	case models.WsFetchCollege:
+		fetchForClient(client, cmd)
+
+	case models.WsListSubscriptions:
+		topics := Subscriptions(client)
+		client.Send(models.WebSocketMessage{Type: models.WsSubscriptions, ID: cmd.ID, Topics: topics, Count: len(topics)})
+
+	default:
+		sendCommandError(client, cmd.ID, models.ErrCodeBadRequest, "",
+			"unknown command "+strings.TrimSpace(cmd.Type)+"; use subscribe, unsubscribe, list_subscriptions or fetch_college")
+	}
+}
+
+// commandTopics merges the single topic and topic list forms of a command
+func commandTopics(cmd models.WebSocketMessage) []string {
+	topics := cmd.Topics
+	if cmd.Topic != "" {
+		topics = append([]string{cmd.Topic}, topics...)
+	}
+	return topics
+}
+
+// subscribeClient subscribes and acknowledges one topic. Following a country
+// also sends its current colleges, as connecting with ?country= does, and
+// following countries sends the country list.
+func subscribeClient(client *Subscriber, id, raw string) {
+	topic, err := ParseTopic(raw)
+	if err != nil {
+		sendCommandError(client, id, models.ErrCodeValidation, raw, err.Error())
+		return
+	}
+	if err := Subscribe(client, topic); err != nil {
+		sendCommandError(client, id, models.ErrCodeConflict, raw, err.Error())
+		return
+	}
+
+	client.Send(models.WebSocketMessage{Type: models.WsSubscribed, ID: id, Topic: topic})
+	switch {
+	case topic == CountriesTopic:
+		SendCountriesUpdate(client)
+	case strings.HasPrefix(topic, "country:"):
+		_, country, _ := strings.Cut(raw, ":")
+		SendCollegesUpdate(strings.TrimSpace(country), client)
+	}
+}
+
+// fetchForClient runs a fetch_college command in the background, sending a
+// fetch_progress message per stage and then fetch_result or error. The fetch
+// is cancelled if the client disconnects.
+func fetchForClient(client *Subscriber, cmd models.WebSocketMessage) {
+	if client.fetches.Add(1) > maxClientFetches {
+		client.fetches.Add(-1)
+		sendCommandError(client, cmd.ID, models.ErrCodeUnavailable, "", "too many fetches in progress on this connection")
+		return
+	}
+
+	go func() {
+		defer client.fetches.Add(-1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-client.Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		stats, cached, err := FetchCollegeWithProgress(ctx, cmd.CollegeName, func(stage string, data map[string]interface{}) {
+			data["stage"] = stage
+			client.Send(models.WebSocketMessage{Type: models.WsFetchProgress, ID: cmd.ID, Data: data})
+		})
+
+		var fetchErr *FetchError
+		if errors.As(err, &fetchErr) {
+			message := models.WebSocketMessage{Type: models.WsError, ID: cmd.ID, Code: fetchErr.Code, Message: fetchErr.Message}
+			if len(fetchErr.Fields) > 0 {
+				message.Data = map[string]interface{}{"fields": fetchErr.Fields}
+			}
+			client.Send(message)
+			return
+		}
+
+		client.Send(models.WebSocketMessage{
+			Type: models.WsFetchResult,
+			ID:   cmd.ID,
+			Data: map[string]interface{}{"college": stats, "cached": cached},
+		})
+	}()
+}
+
+func sendCommandError(client *Subscriber, id, code, topic, message string) {
+	client.Send(models.WebSocketMessage{Type: models.WsError, ID: id, Code: code, Topic: topic, Message: message})
+}
@@ -3,40 +3,30 @@ package services
 import (
 	"context"
 	"log"
-	"sync"
+	"regexp"
 
 	"gobackend/config"
 	"gobackend/models"
+	"gobackend/utils"
 
-	"github.com/gorilla/websocket"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-var (
-	WsClients   = make(map[string]map[*websocket.Conn]bool)
-	WsMutex     sync.RWMutex
-	WsCloseOnce = make(map[*websocket.Conn]bool)
-)
-
-func RegisterClient(country string, conn *websocket.Conn) {
-	WsMutex.Lock()
-	if WsClients[country] == nil {
-		WsClients[country] = make(map[*websocket.Conn]bool)
-	}
-	WsClients[country][conn] = true
-	WsMutex.Unlock()
-}
-
-func UnregisterClient(country string, conn *websocket.Conn) {
-	WsMutex.Lock()
-	delete(WsClients[country], conn)
-	WsMutex.Unlock()
-}
-
-func SendCollegesUpdate(country string, conn *websocket.Conn) {
-	cursor, err := config.CollegeCollection.Find(context.TODO(), bson.M{
-		"country": bson.M{"$regex": "^" + country + "$", "$options": "i"},
-	})
+// SendCollegesUpdate sends the active colleges in country as a paged
+// snapshot: snapshot_begin, one snapshot_page per page of colleges, then
+// snapshot_end with the total. Colleges are read from the cursor a page at a
+// time rather than loaded all at once.
+func SendCollegesUpdate(country string, client *Subscriber) {
+	seq := LatestEventSeq()
+	pageSize := realtimeLimits.snapshotPageSize
+
+	findOptions := options.Find().
+		SetProjection(bson.M{"college_name": 1, "student_statistics": 1}).
+		SetBatchSize(int32(pageSize))
+	cursor, err := config.CollegeCollection.Find(context.TODO(), activeFilter(bson.M{
+		"country": bson.M{"$regex": "^" + regexp.QuoteMeta(country) + "$", "$options": "i"},
+	}), findOptions)
 
 	if err != nil {
 		log.Printf("❌ Error fetching colleges: %v", err)
@@ -44,88 +34,179 @@ func SendCollegesUpdate(country string, conn *websocket.Conn) {
 	}
 	defer cursor.Close(context.TODO())
 
-	var colleges []map[string]interface{}
+	snapshot := newSnapshotWriter(client, "colleges_update", seq, map[string]interface{}{"country": country})
+	if !snapshot.begin() {
+		log.Printf("❌ Error sending colleges update for %s", country)
+		return
+	}
+
+	colleges := make([]map[string]interface{}, 0, pageSize)
 	for cursor.Next(context.TODO()) {
 		var college models.CollegeStats
-		if err := cursor.Decode(&college); err == nil {
-			colleges = append(colleges, map[string]interface{}{
-				"id":      college.CollegeName,
-				"name":    college.CollegeName,
-				"country": country,
-				"data":    college.StudentStatistics,
-			})
+		if err := cursor.Decode(&college); err != nil {
+			continue
+		}
+		colleges = append(colleges, map[string]interface{}{
+			"id":      college.CollegeName,
+			"name":    college.CollegeName,
+			"country": country,
+			"data":    college.StudentStatistics,
+		})
+		if len(colleges) == pageSize {
+			if !snapshot.page("colleges", colleges, len(colleges)) {
+				return
+			}
+			colleges = make([]map[string]interface{}, 0, pageSize)
 		}
 	}
-
-	message := map[string]interface{}{
-		"type":     "colleges_update",
-		"colleges": colleges,
-		"country":  country,
-		"count":    len(colleges),
+	if err := cursor.Err(); err != nil {
+		log.Printf("❌ Error reading colleges for %s: %v", country, err)
 	}
-
-	if err := conn.WriteJSON(message); err != nil {
-		log.Printf("❌ Error sending colleges update: %v", err)
+	if len(colleges) > 0 && !snapshot.page("colleges", colleges, len(colleges)) {
+		return
 	}
+	snapshot.end()
 }
 
-func BroadcastNewCollege(country string, college map[string]interface{}) {
-	WsMutex.RLock()
-	clients := WsClients[country]
-	WsMutex.RUnlock()
+// BroadcastNewCollege notifies clients following country, the college or the
+// global topic about a college written by this process.
+func BroadcastNewCollege(country, collegeID string, college map[string]interface{}) {
+	BroadcastCollegeEvent("new_college", country, collegeID, college)
+}
 
-	if len(clients) == 0 {
+// BroadcastCollegeEvent sends a new_college, college_updated or
+// college_deleted event for a write made by this process. When the change
+// stream watcher is running the write reaches clients through it instead, so
+// nothing is sent here.
+func BroadcastCollegeEvent(eventType, country, collegeID string, college map[string]interface{}) {
+	if ChangeStreamActive() {
 		return
 	}
 
-	message := map[string]interface{}{
-		"type":    "new_college",
-		"college": college,
-		"country": country,
-	}
+	broadcastCollegeMessage(country, collegeID, map[string]interface{}{
+		"type":       eventType,
+		"college":    college,
+		"college_id": collegeID,
+		"country":    country,
+	})
+}
 
-	WsMutex.Lock()
-	for client := range clients {
-		if err := client.WriteJSON(message); err != nil {
-			log.Printf("❌ Error broadcasting new college: %v", err)
-		}
+// BroadcastCollegeUpdated sends a college_updated event listing the fields
+// that differ between before and after, for a write made by this process.
+// Nothing is sent if only timestamps changed, or if the change stream
+// watcher will report the write.
+func BroadcastCollegeUpdated(before, after *models.CollegeStats) {
+	if ChangeStreamActive() {
+		return
+	}
+	if message, ok := collegeUpdatedMessage(*before, *after); ok {
+		broadcastCollegeMessage(after.Country, after.ID.Hex(), message)
 	}
-	WsMutex.Unlock()
 }
 
-func SendCountriesUpdate(conn *websocket.Conn) {
-	cursor, err := config.CollegeCollection.Find(context.TODO(), bson.M{})
-
+// collegeUpdatedMessage builds a college_updated event whose changes are a
+// JSON patch from before to after. ok is false when nothing but timestamps
+// changed.
+func collegeUpdatedMessage(before, after models.CollegeStats) (map[string]interface{}, bool) {
+	changes, err := utils.DiffJSON(before, after, "created_at", "updated_at")
 	if err != nil {
-		log.Printf("❌ Error fetching countries: %v", err)
-		return
+		log.Printf("❌ Error diffing %s: %v", after.CollegeName, err)
+		return nil, false
+	}
+	if len(changes) == 0 {
+		return nil, false
 	}
-	defer cursor.Close(context.TODO())
 
-	countryMap := make(map[string]bool)
-	var countries []map[string]interface{}
+	return map[string]interface{}{
+		"type":       "college_updated",
+		"college":    CollegePayload(after),
+		"college_id": after.ID.Hex(),
+		"country":    after.Country,
+		"changes":    changes,
+	}, true
+}
 
-	for cursor.Next(context.TODO()) {
-		var college models.CollegeStats
-		if err := cursor.Decode(&college); err == nil {
-			if !countryMap[college.Country] {
-				countryMap[college.Country] = true
-				countries = append(countries, map[string]interface{}{
-					"id":   college.Country,
-					"name": college.Country,
-				})
-			}
-		}
+// CollegePayload is the compact college shape sent to WebSocket clients.
+func CollegePayload(college models.CollegeStats) map[string]interface{} {
+	return map[string]interface{}{
+		"id":      college.CollegeName,
+		"name":    college.CollegeName,
+		"country": college.Country,
+		"data":    college.StudentStatistics,
 	}
+}
 
-	message := map[string]interface{}{
-		"type":      "countries_update",
-		"countries": countries,
-		"count":     len(countries),
+// SendCountriesUpdate sends every country with active colleges and how many
+// each has, paged like SendCollegesUpdate. Later changes arrive as
+// country_added, country_removed and country_count_changed events on the
+// countries topic.
+func SendCountriesUpdate(client *Subscriber) {
+	seq := LatestEventSeq()
+	countries := CountryCounts()
+
+	snapshot := newSnapshotWriter(client, "countries_update", seq, nil)
+	if !snapshot.begin() {
+		log.Printf("❌ Error sending countries update")
+		return
+	}
+	for start := 0; start < len(countries); start += realtimeLimits.snapshotPageSize {
+		end := min(start+realtimeLimits.snapshotPageSize, len(countries))
+		if !snapshot.page("countries", countries[start:end], end-start) {
+			return
+		}
 	}
+	if snapshot.end() {
+		log.Printf("📡 Sent %d countries to client", len(countries))
+	}
+}
 
-	if err := conn.WriteJSON(message); err != nil {
-		log.Printf("❌ Error sending countries update: %v", err)
+// snapshotWriter frames one paged snapshot. Every frame names the snapshot
+// kind and carries extra (such as the country), so clients can tell
+// snapshots apart from live events arriving in between.
+type snapshotWriter struct {
+	client *Subscriber
+	kind   string
+	seq    uint64
+	extra  map[string]interface{}
+	pages  int
+	count  int
+}
+
+func newSnapshotWriter(client *Subscriber, kind string, seq uint64, extra map[string]interface{}) *snapshotWriter {
+	return &snapshotWriter{client: client, kind: kind, seq: seq, extra: extra}
+}
+
+func (s *snapshotWriter) frame(frameType string, fields map[string]interface{}) bool {
+	message := map[string]interface{}{"type": frameType, "snapshot": s.kind}
+	for key, value := range s.extra {
+		message[key] = value
 	}
-	log.Printf("📡 Sent %d countries to client", len(countries))
+	for key, value := range fields {
+		message[key] = value
+	}
+	return s.client.SendWait(message)
+}
+
+// begin announces the snapshot and the seq it is consistent with
+func (s *snapshotWriter) begin() bool {
+	return s.frame(models.WsSnapshotBegin, map[string]interface{}{
+		"seq":       s.seq,
+		"page_size": realtimeLimits.snapshotPageSize,
+	})
+}
+
+// page sends the n items under field as the next page
+func (s *snapshotWriter) page(field string, items interface{}, n int) bool {
+	s.pages++
+	s.count += n
+	return s.frame(models.WsSnapshotPage, map[string]interface{}{"page": s.pages, field: items})
+}
+
+// end closes the snapshot with its totals
+func (s *snapshotWriter) end() bool {
+	return s.frame(models.WsSnapshotEnd, map[string]interface{}{
+		"seq":   s.seq,
+		"pages": s.pages,
+		"count": s.count,
+	})
 }
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// JSONFieldNames returns the JSON names of the exported fields of the
+// structs passed in, for validating sparse fieldsets.
+func JSONFieldNames(structs ...interface{}) map[string]bool {
+	names := make(map[string]bool)
+	for _, s := range structs {
+		t := reflect.TypeOf(s)
+		for i := 0; i < t.NumField(); i++ {
+			name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+			if name != "" && name != "-" {
+				names[name] = true
+			}
+		}
+	}
+	return names
+}
+
+// ParseFields reads a comma-separated fields parameter such as
+// "college_name,fees.ug_yearly_min". The first segment of every field must be
+// in allowed. It returns nil when the parameter is absent.
+func ParseFields(raw string, allowed map[string]bool) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var fields, unknown []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !allowed[strings.SplitN(field, ".", 2)[0]] {
+			unknown = append(unknown, field)
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	if len(unknown) > 0 {
+		names := make([]string, 0, len(allowed))
+		for name := range allowed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown fields %s; fields must be among %s", strings.Join(unknown, ", "), strings.Join(names, ", "))
+	}
+	return fields, nil
+}
+
+// SelectFields returns data with only the listed fields of each object, plus
+// "id" so results can still be told apart. data may be an object or a list of
+// objects; dotted fields select inside nested objects. With no fields, data
+// is returned unchanged.
+func SelectFields(data interface{}, fields []string) interface{} {
+	if len(fields) == 0 {
+		return data
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return data
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return data
+	}
+
+	paths := [][]string{{"id"}}
+	for _, field := range fields {
+		paths = append(paths, strings.Split(field, "."))
+	}
+
+	switch v := generic.(type) {
+	case []interface{}:
+		for i, item := range v {
+			if obj, ok := item.(map[string]interface{}); ok {
+				v[i] = pickPaths(obj, paths)
+			}
+		}
+		return v
+	case map[string]interface{}:
+		return pickPaths(v, paths)
+	}
+	return generic
+}
+
+// pickPaths copies the values at paths out of obj, keeping their nesting
+func pickPaths(obj map[string]interface{}, paths [][]string) map[string]interface{} {
+	picked := make(map[string]interface{})
+	nested := make(map[string][][]string)
+
+	for _, path := range paths {
+		value, ok := obj[path[0]]
+		if !ok {
+			continue
+		}
+		if len(path) == 1 {
+			picked[path[0]] = value
+			delete(nested, path[0])
+			continue
+		}
+		if _, whole := picked[path[0]]; !whole {
+			nested[path[0]] = append(nested[path[0]], path[1:])
+		}
+	}
+
+	for key, subPaths := range nested {
+		if child, ok := obj[key].(map[string]interface{}); ok {
+			picked[key] = pickPaths(child, subPaths)
+		}
+	}
+	return picked
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+type fieldsFees struct {
+	UGMin int `json:"ug_yearly_min"`
+	UGMax int `json:"ug_yearly_max"`
+}
+
+type fieldsCollege struct {
+	ID      string     `json:"id"`
+	Name    string     `json:"college_name"`
+	Country string     `json:"country"`
+	Fees    fieldsFees `json:"fees"`
+	Secret  string     `json:"-"`
+}
+
+func TestJSONFieldNames(t *testing.T) {
+	want := map[string]bool{"id": true, "college_name": true, "country": true, "fees": true}
+	if got := JSONFieldNames(fieldsCollege{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("JSONFieldNames = %v, want %v", got, want)
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	allowed := JSONFieldNames(fieldsCollege{})
+
+	got, err := ParseFields(" college_name, ,fees.ug_yearly_min ", allowed)
+	if err != nil || !reflect.DeepEqual(got, []string{"college_name", "fees.ug_yearly_min"}) {
+		t.Errorf("ParseFields = %q, %v", got, err)
+	}
+	if got, err := ParseFields("", allowed); got != nil || err != nil {
+		t.Errorf("ParseFields(\"\") = %q, %v; want nil, nil", got, err)
+	}
+	if _, err := ParseFields("college_name,secret.key", allowed); err == nil {
+		t.Error("ParseFields accepted an unknown field")
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	college := fieldsCollege{ID: "1", Name: "IIT Madras", Country: "India", Fees: fieldsFees{UGMin: 200000, UGMax: 250000}}
+
+	tests := []struct {
+		name   string
+		data   interface{}
+		fields []string
+		want   interface{}
+	}{
+		{"top-level field keeps id", college, []string{"college_name"}, map[string]interface{}{
+			"id": "1", "college_name": "IIT Madras",
+		}},
+		{"dotted field", college, []string{"fees.ug_yearly_min"}, map[string]interface{}{
+			"id": "1", "fees": map[string]interface{}{"ug_yearly_min": float64(200000)},
+		}},
+		{"whole object wins over a dotted field", college, []string{"fees.ug_yearly_min", "fees"}, map[string]interface{}{
+			"id": "1", "fees": map[string]interface{}{"ug_yearly_min": float64(200000), "ug_yearly_max": float64(250000)},
+		}},
+		{"missing fields are skipped", college, []string{"fees.phd_yearly_min", "nope"}, map[string]interface{}{
+			"id": "1", "fees": map[string]interface{}{},
+		}},
+		{"every item of a list", []fieldsCollege{college, {ID: "2", Country: "Japan"}}, []string{"country"}, []interface{}{
+			map[string]interface{}{"id": "1", "country": "India"},
+			map[string]interface{}{"id": "2", "country": "Japan"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SelectFields(tt.data, tt.fields); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectFields = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	if got := SelectFields(college, nil); !reflect.DeepEqual(got, college) {
+		t.Errorf("SelectFields with no fields = %#v, want the data unchanged", got)
+	}
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// SearchTerms splits a $text style query into the plain terms worth
+// highlighting, skipping negated terms and quote characters.
+func SearchTerms(query string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, word := range strings.Fields(strings.ReplaceAll(query, `"`, " ")) {
+		if strings.HasPrefix(word, "-") {
+			continue
+		}
+		word = strings.ToLower(strings.Trim(word, ".,;:!?()[]{}"))
+		if utf8.RuneCountInString(word) < 2 || seen[word] {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+// TermMatcher builds a case-insensitive pattern matching any of terms,
+// preferring the longest term at each position. It returns nil for no terms.
+func TermMatcher(terms []string) *regexp.Regexp {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	sorted := append([]string(nil), terms...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	quoted := make([]string, len(sorted))
+	for i, term := range sorted {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	return regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+}
+
+// HighlightSnippet returns an HTML-escaped excerpt of text centred on the
+// first match of matcher, with every match wrapped in <mark> tags. radius is
+// the number of bytes of context kept on each side. ok is false when nothing matches.
+func HighlightSnippet(text string, matcher *regexp.Regexp, radius int) (snippet string, ok bool) {
+	if matcher == nil {
+		return "", false
+	}
+	first := matcher.FindStringIndex(text)
+	if first == nil {
+		return "", false
+	}
+
+	start := first[0] - radius
+	if start <= 0 {
+		start = 0
+	} else {
+		// Start on a word boundary so the excerpt doesn't open mid-word.
+		if space := strings.IndexByte(text[start:first[0]], ' '); space >= 0 {
+			start += space + 1
+		}
+		for start < first[0] && !utf8.RuneStart(text[start]) {
+			start++
+		}
+	}
+
+	end := first[1] + radius
+	if end >= len(text) {
+		end = len(text)
+	} else {
+		if space := strings.LastIndexByte(text[first[1]:end], ' '); space >= 0 {
+			end = first[1] + space
+		}
+		for end < len(text) && !utf8.RuneStart(text[end]) {
+			end++
+		}
+	}
+
+	excerpt := text[start:end]
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("…")
+	}
+	last := 0
+	for _, loc := range matcher.FindAllStringIndex(excerpt, -1) {
+		sb.WriteString(html.EscapeString(excerpt[last:loc[0]]))
+		sb.WriteString("<mark>")
+		sb.WriteString(html.EscapeString(excerpt[loc[0]:loc[1]]))
+		sb.WriteString("</mark>")
+		last = loc[1]
+	}
+	sb.WriteString(html.EscapeString(excerpt[last:]))
+	if end < len(text) {
+		sb.WriteString("…")
+	}
+
+	return sb.String(), true
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchTerms(t *testing.T) {
+	got := SearchTerms(`"Indian Institute" -fees institute a (MIT),`)
+	want := []string{"indian", "institute", "mit"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SearchTerms = %q, want %q", got, want)
+	}
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		terms  []string
+		radius int
+		want   string
+		ok     bool
+	}{
+		{"no terms", "Top engineering school", nil, 50, "", false},
+		{"no match", "Top engineering school", []string{"law"}, 50, "", false},
+		{"whole text", "Top engineering school", []string{"engineering"}, 50, "Top <mark>engineering</mark> school", true},
+		{"every match case-insensitively", "MIT and mit", []string{"mit"}, 50, "<mark>MIT</mark> and <mark>mit</mark>", true},
+		{"longest term wins", "technology", []string{"tech", "technology"}, 50, "<mark>technology</mark>", true},
+		{"html escaped", "A&M <b>law</b>", []string{"law"}, 50, "A&amp;M &lt;b&gt;<mark>law</mark>&lt;/b&gt;", true},
+		{"trimmed to words", "one two three four five six seven", []string{"four"}, 6, "…<mark>four</mark> five…", true},
+		{"trimmed to runes", "éééééélaw", []string{"law"}, 3, "…é<mark>law</mark>", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := HighlightSnippet(tt.text, TermMatcher(tt.terms), tt.radius)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("HighlightSnippet(%q) = %q, %v; want %q, %v", tt.text, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gobackend/models"
+)
+
+// DiffJSON returns the JSON-patch operations that turn before into after,
+// comparing their JSON encodings. Objects are compared field by field;
+// arrays and scalars are replaced whole. Top-level fields named in ignore,
+// such as timestamps, are skipped.
+func DiffJSON(before, after interface{}, ignore ...string) ([]models.PatchOperation, error) {
+	from, err := toGenericJSON(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := toGenericJSON(after)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range ignore {
+		if obj, ok := from.(map[string]interface{}); ok {
+			delete(obj, field)
+		}
+		if obj, ok := to.(map[string]interface{}); ok {
+			delete(obj, field)
+		}
+	}
+
+	ops := []models.PatchOperation{}
+	diffValues("", from, to, &ops)
+	return ops, nil
+}
+
+func toGenericJSON(v interface{}) (interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	err = json.Unmarshal(raw, &generic)
+	return generic, err
+}
+
+func diffValues(path string, from, to interface{}, ops *[]models.PatchOperation) {
+	fromObj, fromIsObj := from.(map[string]interface{})
+	toObj, toIsObj := to.(map[string]interface{})
+	if !fromIsObj || !toIsObj {
+		if !reflect.DeepEqual(from, to) {
+			*ops = append(*ops, models.PatchOperation{Op: models.PatchReplace, Path: path, Value: to})
+		}
+		return
+	}
+
+	keys := make([]string, 0, len(fromObj)+len(toObj))
+	for key := range fromObj {
+		keys = append(keys, key)
+	}
+	for key := range toObj {
+		if _, ok := fromObj[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		child := path + "/" + escapePointer(key)
+		oldValue, hadOld := fromObj[key]
+		newValue, hasNew := toObj[key]
+		switch {
+		case !hasNew:
+			*ops = append(*ops, models.PatchOperation{Op: models.PatchRemove, Path: child})
+		case !hadOld:
+			*ops = append(*ops, models.PatchOperation{Op: models.PatchAdd, Path: child, Value: newValue})
+		default:
+			diffValues(child, oldValue, newValue, ops)
+		}
+	}
+}
+
+// escapePointer escapes a key for use as a JSON pointer segment (RFC 6901)
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
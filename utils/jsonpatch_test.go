@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gobackend/models"
+)
+
+func TestDiffJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+		ignore []string
+		want   []models.PatchOperation
+	}{
+		{"unchanged", map[string]interface{}{"a": 1}, map[string]interface{}{"a": 1}, nil, []models.PatchOperation{}},
+		{"nested replace", map[string]interface{}{"fees": map[string]int{"ug": 1, "pg": 2}}, map[string]interface{}{"fees": map[string]int{"ug": 3, "pg": 2}}, nil, []models.PatchOperation{
+			{Op: models.PatchReplace, Path: "/fees/ug", Value: float64(3)},
+		}},
+		{"add and remove in key order", map[string]interface{}{"b": "x", "c": true}, map[string]interface{}{"a": nil, "c": true}, nil, []models.PatchOperation{
+			{Op: models.PatchAdd, Path: "/a", Value: nil},
+			{Op: models.PatchRemove, Path: "/b"},
+		}},
+		{"arrays are replaced whole", map[string]interface{}{"tags": []string{"a", "b"}}, map[string]interface{}{"tags": []string{"a"}}, nil, []models.PatchOperation{
+			{Op: models.PatchReplace, Path: "/tags", Value: []interface{}{"a"}},
+		}},
+		{"object replaced by scalar", map[string]interface{}{"x": map[string]int{"y": 1}}, map[string]interface{}{"x": 2}, nil, []models.PatchOperation{
+			{Op: models.PatchReplace, Path: "/x", Value: float64(2)},
+		}},
+		{"pointer escaping", map[string]interface{}{"a/b~c": 1}, map[string]interface{}{"a/b~c": 2}, nil, []models.PatchOperation{
+			{Op: models.PatchReplace, Path: "/a~1b~0c", Value: float64(2)},
+		}},
+		{"ignored fields", map[string]interface{}{"updated_at": "then", "n": 1}, map[string]interface{}{"updated_at": "now", "n": 1}, []string{"updated_at"}, []models.PatchOperation{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DiffJSON(tt.before, tt.after, tt.ignore...)
+			if err != nil {
+				t.Fatalf("DiffJSON: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffJSON = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchOperationJSON(t *testing.T) {
+	tests := []struct {
+		op   models.PatchOperation
+		want string
+	}{
+		{models.PatchOperation{Op: models.PatchRemove, Path: "/a"}, `{"op":"remove","path":"/a"}`},
+		{models.PatchOperation{Op: models.PatchAdd, Path: "/a", Value: nil}, `{"op":"add","path":"/a","value":null}`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.op)
+		if err != nil || string(got) != tt.want {
+			t.Errorf("json.Marshal(%+v) = %s, %v; want %s", tt.op, got, err, tt.want)
+		}
+	}
+}
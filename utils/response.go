@@ -3,10 +3,43 @@ package utils
 import (
 	"encoding/json"
 	"net/http"
+	"time"
+
+	"gobackend/models"
 )
 
+// RespondJSON writes data as-is. REST handlers use RespondSuccess and
+// RespondError; this is for protocols with their own response shape, such as
+// GraphQL.
 func RespondJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(data)
 }
+
+// RespondSuccess wraps data in the standard envelope. meta is only set for
+// lists. The ETag is a hash of the envelope, so identical responses share it.
+func RespondSuccess(w http.ResponseWriter, status int, data interface{}, meta *models.ResponseMeta) {
+	response := models.APIResponse{Success: true, Data: data, Meta: meta}
+	if hash := GenerateDataHash(response); hash != "" {
+		w.Header().Set("ETag", `"`+hash+`"`)
+	}
+	RespondJSON(w, status, response)
+}
+
+// SetLastModified sets the Last-Modified header unless t is zero
+func SetLastModified(w http.ResponseWriter, t time.Time) {
+	if !t.IsZero() {
+		w.Header().Set("Last-Modified", t.UTC().Format(http.TimeFormat))
+	}
+}
+
+// RespondError writes an error envelope with the given code and message
+func RespondError(w http.ResponseWriter, status int, code, message string) {
+	RespondAPIError(w, status, models.APIError{Code: code, Message: message})
+}
+
+// RespondAPIError writes an error envelope carrying field errors or details
+func RespondAPIError(w http.ResponseWriter, status int, apiErr models.APIError) {
+	RespondJSON(w, status, models.APIResponse{Success: false, Error: &apiErr})
+}